@@ -0,0 +1,240 @@
+package faket
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// annotationPrefix marks a comment with expected log messages.
+const annotationPrefix = "faket:want"
+
+// annotation is a single expected log message on a line.
+type annotation struct {
+	line    int
+	want    string
+	matched bool
+}
+
+// MustMatchAnnotations ensures the test's logs match annotations in the
+// source of the test function passed to [RunTest]. Annotations are comments
+// with one or more quoted strings on the line expected to log, such as:
+//
+//	want.Equal(t, "count", got, 2) // faket:want "count: expected equal"
+//
+// Each log must be attributed to an annotated line, with a message containing
+// one of the line's expected strings, and each expected string must match
+// a separate log. Otherwise, it will report a fatal failure to `t`.
+//
+// Results from [Spy] have no test function, so are not supported.
+func (tr TestResult) MustMatchAnnotations(t testing.TB) {
+	t.Helper()
+
+	tr.mustHaveTestFn(t)
+	file, line, err := tr.res.testFnSource()
+	if err != nil {
+		t.Fatalf("failed to parse annotations: %v", err)
+	}
+
+	annotations, err := parseAnnotations(file, line)
+	if err != nil {
+		t.Fatalf("failed to parse annotations: %v", err)
+	}
+
+	byLine := make(map[int][]*annotation)
+	for _, a := range annotations {
+		byLine[a.line] = append(byLine[a.line], a)
+	}
+
+	var problems []string
+	for _, l := range tr.Logs() {
+		if l.CallerFile != file {
+			problems = append(problems, fmt.Sprintf("unexpected log outside test function: %v", Logs{l}))
+			continue
+		}
+
+		lineAnnotations := byLine[l.CallerLine]
+		if len(lineAnnotations) == 0 {
+			problems = append(problems, fmt.Sprintf("unexpected log on line without annotation: %v", Logs{l}))
+			continue
+		}
+
+		if !matchAnnotation(lineAnnotations, l.Message) {
+			wants := make([]string, len(lineAnnotations))
+			for i, a := range lineAnnotations {
+				wants[i] = strconv.Quote(a.want)
+			}
+			problems = append(problems, fmt.Sprintf("log does not match annotation %v: %v", strings.Join(wants, " "), Logs{l}))
+		}
+	}
+
+	for _, a := range annotations {
+		if !a.matched {
+			problems = append(problems, fmt.Sprintf("missing log for annotation %q at %v:%v\n", a.want, file, a.line))
+		}
+	}
+
+	if len(problems) > 0 {
+		t.Fatalf("logs do not match annotations:\n%v", indentLines(strings.Join(problems, "")))
+	}
+}
+
+// matchAnnotation marks the first unmatched annotation contained in msg as matched.
+func matchAnnotation(annotations []*annotation, msg string) bool {
+	for _, a := range annotations {
+		if !a.matched && strings.Contains(msg, a.want) {
+			a.matched = true
+			return true
+		}
+	}
+	return false
+}
+
+// testFnSource returns the file and line of the test function.
+// Method values (e.g., s.testMethod) use a generated wrapper with a "-fm"
+// suffix, so the method is found using the location of a log from it.
+func (tb *fakeTB) testFnSource() (file string, line int, _ error) {
+	name, file, line := funcInfo(tb.testFn)
+	method, ok := strings.CutSuffix(name, "-fm")
+	if !ok {
+		return file, line, nil
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	for _, e := range tb.logs {
+		for _, callers := range [][]uintptr{e.callers, e.cleanupCallers} {
+			frames := runtime.CallersFrames(callers)
+			for {
+				f, more := frames.Next()
+				if f.Function == method {
+					line, err := funcDeclLine(f.File, f.Line)
+					return f.File, line, err
+				}
+				if !more {
+					break
+				}
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("cannot find the source of method %v without any logs from it", method)
+}
+
+// funcDeclLine returns the line of the function declaration
+// that contains the given line.
+func funcDeclLine(file string, line int) (int, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			start, end := fset.Position(fn.Pos()).Line, fset.Position(fn.End()).Line
+			if start <= line && line <= end {
+				return start, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("cannot find function containing %v:%v", file, line)
+}
+
+// parseAnnotations returns the annotations in the function starting at
+// the given line of file, ordered by line.
+func parseAnnotations(file string, line int) ([]*annotation, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	fn := findFunc(fset, f, line)
+	if fn == nil {
+		return nil, fmt.Errorf("cannot find function at %v:%v", file, line)
+	}
+	start, end := fset.Position(fn.Pos()).Line, fset.Position(fn.End()).Line
+
+	var annotations []*annotation
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			cLine := fset.Position(c.Pos()).Line
+			if cLine < start || cLine > end {
+				continue
+			}
+
+			_, text, ok := strings.Cut(c.Text, annotationPrefix)
+			if !ok {
+				continue
+			}
+
+			wants, err := parseQuoted(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid annotation at %v:%v: %v", file, cLine, err)
+			}
+			for _, w := range wants {
+				annotations = append(annotations, &annotation{line: cLine, want: w})
+			}
+		}
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].line < annotations[j].line
+	})
+	return annotations, nil
+}
+
+// findFunc returns the outermost function declaration or literal starting on line.
+func findFunc(fset *token.FileSet, f *ast.File, line int) ast.Node {
+	var found ast.Node
+	ast.Inspect(f, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			if fset.Position(n.Pos()).Line == line {
+				found = n
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// parseQuoted parses a space-separated list of Go quoted strings.
+func parseQuoted(s string) ([]string, error) {
+	var quoted []string
+	for {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			break
+		}
+
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected quoted string: %v", s)
+		}
+		unquoted, err := strconv.Unquote(q)
+		if err != nil {
+			return nil, err
+		}
+
+		quoted = append(quoted, unquoted)
+		s = s[len(q):]
+	}
+
+	if len(quoted) == 0 {
+		return nil, fmt.Errorf("no quoted strings after %v", annotationPrefix)
+	}
+	return quoted, nil
+}
@@ -0,0 +1,103 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestMustMatchAnnotations(t *testing.T) {
+	tests := []struct {
+		name         string
+		fn           func(testing.TB)
+		wantContains []string
+	}{
+		{
+			name: "matches",
+			fn: func(t testing.TB) {
+				t.Log("log")                       // faket:want "log"
+				errorHelper(t, "failed 1")         // faket:want "failed 1"
+				logAll(t, "failed 2", "same line") // faket:want `same line` "failed 2"
+				panic("boom")                      // faket:want "panic: boom"
+			},
+		},
+		{
+			name: "no logs or annotations",
+			fn:   func(t testing.TB) {},
+		},
+		{
+			name: "unannotated log",
+			fn: func(t testing.TB) {
+				t.Log("log")
+			},
+			wantContains: []string{"unexpected log on line without annotation: annotations_test.go:"},
+		},
+		{
+			name: "log from helper",
+			fn: func(t testing.TB) {
+				errorNotHelper(t, "failed") // faket:want "failed"
+			},
+			wantContains: []string{
+				"unexpected log outside test function: test_helpers_test.go:",
+				`missing log for annotation "failed" at`,
+			},
+		},
+		{
+			name: "mismatched message",
+			fn: func(t testing.TB) {
+				t.Log("log") // faket:want "other"
+			},
+			wantContains: []string{
+				`log does not match annotation "other": annotations_test.go:`,
+				`missing log for annotation "other"`,
+			},
+		},
+		{
+			name: "fewer logs than annotations",
+			fn: func(t testing.TB) {
+				t.Log("log") // faket:want "log" "log"
+			},
+			wantContains: []string{`missing log for annotation "log"`},
+		},
+		{
+			name: "invalid annotation",
+			fn: func(t testing.TB) {
+				t.Log("log") // faket:want log
+			},
+			wantContains: []string{"failed to parse annotations: invalid annotation at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fnTR := RunTest(tt.fn)
+			tr := RunTest(func(t testing.TB) {
+				fnTR.MustMatchAnnotations(t)
+			})
+			want.Equal(t, "Failed", tr.Failed(), len(tt.wantContains) > 0)
+			for _, s := range tt.wantContains {
+				want.Contains(t, "Message", tr.Logs().String(), s)
+			}
+		})
+	}
+}
+
+func logAll(t testing.TB, msgs ...string) {
+	t.Helper()
+
+	for _, msg := range msgs {
+		t.Log(msg)
+	}
+}
+
+func TestParseQuoted(t *testing.T) {
+	got, err := parseQuoted(` "a" ` + "`b`" + ` "c\"d"`)
+	want.NoErr(t, err)
+	want.DeepEqual(t, "parseQuoted", got, []string{"a", "b", `c"d`})
+
+	_, err = parseQuoted("")
+	want.Contains(t, "empty error", err.Error(), "no quoted strings")
+
+	_, err = parseQuoted(`"a" b`)
+	want.Contains(t, "unquoted error", err.Error(), "expected quoted string: b")
+}
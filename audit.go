@@ -0,0 +1,209 @@
+package faket
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SideEffect is a change to the process or filesystem made by a test
+// that was not restored by the end of the test.
+type SideEffect struct {
+	Kind SideEffectKind
+
+	// Name is the environment variable or file path that was changed.
+	// It's empty for changes to the working directory.
+	Name string
+
+	// Before and After describe the state before and after the test.
+	Before string
+	After  string
+}
+
+// SideEffectKind is the kind of state modified by a side effect.
+type SideEffectKind int
+
+// SideEffectKind values.
+const (
+	// SideEffectEnv is used for changes to environment variables.
+	SideEffectEnv SideEffectKind = iota + 1
+	// SideEffectCwd is used for changes to the working directory.
+	SideEffectCwd
+	// SideEffectFile is used for files created, modified or removed
+	// in audited directories.
+	SideEffectFile
+)
+
+// Descriptions of missing state in SideEffect.
+const (
+	envUnset    = "<unset>"
+	fileMissing = "<missing>"
+)
+
+func (k SideEffectKind) String() string {
+	switch k {
+	case SideEffectEnv:
+		return "env"
+	case SideEffectCwd:
+		return "cwd"
+	case SideEffectFile:
+		return "file"
+	default:
+		return fmt.Sprintf("SideEffectKind(%d)", int(k))
+	}
+}
+
+// String returns a description of the side effect, such as:
+//
+//	env FOO: "bar" -> <unset>
+func (e SideEffect) String() string {
+	name := e.Kind.String()
+	if e.Name != "" {
+		name += " " + e.Name
+	}
+	return fmt.Sprintf("%v: %v -> %v", name, e.Before, e.After)
+}
+
+// SideEffects returns changes to the environment, working directory
+// and audited directories that were not restored by the end of the test.
+// It's only set if the test was run with [Opts.Audit].
+func (r TestResult) SideEffects() []SideEffect {
+	r.res.mu.Lock()
+	defer r.res.mu.Unlock()
+
+	return append([]SideEffect(nil), r.res.sideEffects...)
+}
+
+// processSnapshot is the state of the process and audited directories.
+type processSnapshot struct {
+	env   map[string]string
+	cwd   string
+	files map[string]string // path to description of the file
+}
+
+// absDirs returns absolute paths for dirs, so they're unaffected
+// by changes to the working directory.
+func absDirs(dirs []string) []string {
+	abs := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if a, err := filepath.Abs(d); err == nil {
+			d = a
+		}
+		abs = append(abs, d)
+	}
+	return abs
+}
+
+func takeSnapshot(dirs []string) processSnapshot {
+	s := processSnapshot{
+		env:   make(map[string]string),
+		files: make(map[string]string),
+	}
+
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		s.env[k] = v
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		s.cwd = cwd
+	}
+
+	for _, d := range dirs {
+		// Errors are ignored, as unreadable files can't be compared.
+		_ = filepath.WalkDir(d, func(path string, de fs.DirEntry, err error) error {
+			if err != nil {
+				return nil //nolint:nilerr // skip unreadable paths.
+			}
+			info, err := de.Info()
+			if err != nil {
+				return nil //nolint:nilerr // skip files removed while walking.
+			}
+			s.files[path] = describeFile(info)
+			return nil
+		})
+	}
+	return s
+}
+
+func describeFile(info fs.FileInfo) string {
+	if info.IsDir() {
+		return info.Mode().String()
+	}
+	return fmt.Sprintf("%v %d bytes, modified %v", info.Mode(), info.Size(), info.ModTime().Format(time.RFC3339Nano))
+}
+
+// sideEffects returns the differences from before to after.
+func (before processSnapshot) sideEffects(after processSnapshot) []SideEffect {
+	var effects []SideEffect
+
+	for _, k := range unionKeys(before.env, after.env) {
+		b, bOK := before.env[k]
+		a, aOK := after.env[k]
+		if b == a && bOK == aOK {
+			continue
+		}
+		effects = append(effects, SideEffect{
+			Kind:   SideEffectEnv,
+			Name:   k,
+			Before: describeEnv(b, bOK),
+			After:  describeEnv(a, aOK),
+		})
+	}
+
+	if before.cwd != after.cwd {
+		effects = append(effects, SideEffect{
+			Kind:   SideEffectCwd,
+			Before: before.cwd,
+			After:  after.cwd,
+		})
+	}
+
+	for _, path := range unionKeys(before.files, after.files) {
+		b, bOK := before.files[path]
+		a, aOK := after.files[path]
+		if b == a && bOK == aOK {
+			continue
+		}
+		if !bOK {
+			b = fileMissing
+		}
+		if !aOK {
+			a = fileMissing
+		}
+		effects = append(effects, SideEffect{
+			Kind:   SideEffectFile,
+			Name:   path,
+			Before: b,
+			After:  a,
+		})
+	}
+
+	return effects
+}
+
+func describeEnv(v string, ok bool) string {
+	if !ok {
+		return envUnset
+	}
+	return fmt.Sprintf("%q", v)
+}
+
+// unionKeys returns the sorted keys that are in either map.
+func unionKeys(m1, m2 map[string]string) []string {
+	keys := make([]string, 0, len(m1))
+	for k := range m1 {
+		keys = append(keys, k)
+	}
+	for k := range m2 {
+		if _, ok := m1[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+package faket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestAuditSideEffects(t *testing.T) {
+	const envKey = "FAKET_AUDIT_TEST"
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	want.NoErr(t, os.WriteFile(existing, []byte("data"), 0o600))
+
+	wd, err := os.Getwd()
+	want.NoErr(t, err)
+
+	tests := []struct {
+		name      string
+		fn        func(t testing.TB)
+		undo      func(t testing.TB)
+		wantKinds []SideEffectKind
+		wantNames []string
+		wantStr   []string
+	}{
+		{
+			name: "restored by cleanups",
+			fn: func(t testing.TB) {
+				t.Setenv(envKey, "value")
+				newFile := filepath.Join(dir, "new")
+				want.NoErr(t, os.WriteFile(newFile, nil, 0o600))
+				t.Cleanup(func() {
+					want.NoErr(t, os.Remove(newFile))
+				})
+			},
+		},
+		{
+			name: "env set",
+			fn: func(t testing.TB) {
+				want.NoErr(t, os.Setenv(envKey, "value"))
+			},
+			undo:      func(t testing.TB) { want.NoErr(t, os.Unsetenv(envKey)) },
+			wantKinds: []SideEffectKind{SideEffectEnv},
+			wantNames: []string{envKey},
+			wantStr:   []string{`env FAKET_AUDIT_TEST: <unset> -> "value"`},
+		},
+		{
+			name: "cwd changed",
+			fn: func(t testing.TB) {
+				want.NoErr(t, os.Chdir(dir))
+			},
+			undo:      func(t testing.TB) { want.NoErr(t, os.Chdir(wd)) },
+			wantKinds: []SideEffectKind{SideEffectCwd},
+			wantNames: []string{""},
+			wantStr:   []string{"cwd: " + wd + " -> " + dir},
+		},
+		{
+			name: "files created and removed",
+			fn: func(t testing.TB) {
+				want.NoErr(t, os.WriteFile(filepath.Join(dir, "leaked"), nil, 0o600))
+				want.NoErr(t, os.Remove(existing))
+			},
+			undo: func(t testing.TB) {
+				want.NoErr(t, os.Remove(filepath.Join(dir, "leaked")))
+				want.NoErr(t, os.WriteFile(existing, []byte("data"), 0o600))
+			},
+			wantKinds: []SideEffectKind{SideEffectFile, SideEffectFile},
+			wantNames: []string{existing, filepath.Join(dir, "leaked")},
+			wantStr:   []string{"file " + existing + ": -rw------- 4 bytes, modified ", "-> <missing>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTestOpts(Opts{Audit: true, AuditDirs: []string{dir}}, tt.fn)
+			if tt.undo != nil {
+				tt.undo(t)
+			}
+			tr.MustPass(t)
+
+			effects := tr.SideEffects()
+			want.Equal(t, "side effects count", len(effects), len(tt.wantKinds))
+			var effectsStr string
+			for i, e := range effects {
+				want.Equal(t, "Kind", e.Kind, tt.wantKinds[i])
+				want.Equal(t, "Name", e.Name, tt.wantNames[i])
+				effectsStr += e.String() + "\n"
+			}
+			for _, s := range tt.wantStr {
+				want.Contains(t, "SideEffects", effectsStr, s)
+			}
+		})
+	}
+}
+
+func TestAuditDisabled(t *testing.T) {
+	tr := RunTest(func(t testing.TB) {
+		want.NoErr(t, os.Setenv("FAKET_AUDIT_TEST", "value"))
+	})
+	want.NoErr(t, os.Unsetenv("FAKET_AUDIT_TEST"))
+
+	tr.MustPass(t)
+	want.Equal(t, "side effects count", len(tr.SideEffects()), 0)
+}
@@ -0,0 +1,54 @@
+//go:build go1.24
+
+package faket
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestBehaviorsContext(t *testing.T) {
+	type ctxKey struct{}
+
+	var testCtx context.Context
+	tr := RunTestOpts(Opts{
+		Behaviors: Behaviors{
+			Context: func() context.Context {
+				return context.WithValue(context.Background(), ctxKey{}, "seeded")
+			},
+		},
+	}, func(t testing.TB) {
+		testCtx = t.Context()
+		t.Log("value:", t.Context().Value(ctxKey{}))
+		t.Log("err:", t.Context().Err())
+	})
+
+	tr.MustPass(t)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"value: seeded", "err: <nil>"})
+	want.Equal(t, "cancelled after test", testCtx.Err(), context.Canceled)
+}
+
+func TestBehaviorsChdir(t *testing.T) {
+	wd, err := os.Getwd()
+	want.NoErr(t, err)
+
+	var dirs []string
+	RunTestOpts(Opts{
+		Behaviors: Behaviors{
+			Chdir: func(t testing.TB, dir string) {
+				dirs = append(dirs, dir)
+			},
+		},
+	}, func(t testing.TB) {
+		t.Chdir(os.TempDir())
+	}).MustPass(t)
+
+	want.DeepEqual(t, "Chdir calls", dirs, []string{os.TempDir()})
+
+	gotWd, err := os.Getwd()
+	want.NoErr(t, err)
+	want.Equal(t, "working directory", gotWd, wd)
+}
@@ -0,0 +1,71 @@
+package faket
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestBehaviors(t *testing.T) {
+	base := t.TempDir()
+
+	env := make(map[string]string)
+	opts := Opts{
+		Behaviors: Behaviors{
+			Name: func() string { return "TestCustom/sub" },
+			TempDir: func(t testing.TB) string {
+				d, err := os.MkdirTemp(base, "custom")
+				if err != nil {
+					t.Fatalf("TempDir: %v", err)
+				}
+				t.Cleanup(func() {
+					t.Log("removing custom TempDir")
+					if err := os.RemoveAll(d); err != nil {
+						t.Errorf("TempDir RemoveAll cleanup: %v", err)
+					}
+				})
+				return d
+			},
+			Setenv: func(t testing.TB, key, value string) {
+				if strings.Contains(key, "=") {
+					t.Fatalf("invalid key: %v", key)
+				}
+				env[key] = value
+			},
+		},
+	}
+
+	var dir string
+	tr := RunTestOpts(opts, func(t testing.TB) {
+		want.Equal(t, "Name", t.Name(), "TestCustom/sub")
+		dir = t.TempDir()
+		t.Setenv("FAKET_BEHAVIORS_KEY", "value")
+		t.Setenv("INVALID=KEY", "value")
+	})
+
+	tr.MustFail(t, "invalid key: INVALID=KEY")
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"invalid key: INVALID=KEY", "removing custom TempDir"})
+	want.Equal(t, "TempDir parent", filepath.Dir(dir), base)
+	want.DeepEqual(t, "TempDirs", tr.TempDirs(), []string{dir})
+	want.Equal(t, "normalized TempDir", tr.Normalizer().Normalize("dir: "+dir+"/file"), "dir: $TEMPDIR1/file")
+	want.DeepEqual(t, "env", env, map[string]string{"FAKET_BEHAVIORS_KEY": "value"})
+
+	_, ok := os.LookupEnv("FAKET_BEHAVIORS_KEY")
+	want.Equal(t, "process env set", ok, false)
+}
+
+func TestBehaviorsNameUsedByTempDir(t *testing.T) {
+	var dir string
+	RunTestOpts(Opts{
+		Behaviors: Behaviors{
+			Name: func() string { return "TestCustom/sub" },
+		},
+	}, func(t testing.TB) {
+		dir = t.TempDir()
+	}).MustPass(t)
+
+	want.Contains(t, "TempDir parent", filepath.Base(filepath.Dir(dir)), "TestCustomsub")
+}
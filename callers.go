@@ -36,3 +36,15 @@ func pcToFunction(pc uintptr) string {
 	f, _ := frames.Next()
 	return f.Function
 }
+
+// funcInfo returns the name and location of the function with the given entry PC.
+// Unlike pcToFunction, pc is not treated as a return address.
+func funcInfo(entry uintptr) (name, file string, line int) {
+	fn := runtime.FuncForPC(entry)
+	if fn == nil {
+		return "", "", 0
+	}
+
+	file, line = fn.FileLine(entry)
+	return fn.Name(), file, line
+}
@@ -0,0 +1,227 @@
+package faket
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket/internal/diff"
+	"github.com/prashantv/faket/internal/sliceutil"
+)
+
+// String formats the call similar to Go syntax, such as `Errorf("got %v", 1)`.
+func (c Call) String() string {
+	return c.Method + "(" + strings.Join(sliceutil.Map(c.Args, formatArg), ", ") + ")"
+}
+
+func formatArg(arg any) string {
+	if v := reflect.ValueOf(arg); v.Kind() == reflect.Func {
+		return "func"
+	}
+	return fmt.Sprintf("%#v", arg)
+}
+
+// Calls returns the calls to the fake [testing.TB] made by the test,
+// in the order they were made. Calls are only recorded when using [Opts.RecordCalls].
+//
+// Calls are recorded as made by the test, before any [Opts.Interceptors].
+// Calls made by [Hooks] and [Behaviors], and logs from [ReplayTo] or
+// [ForwardTo], still go through Opts.Interceptors, but aren't recorded.
+func (r TestResult) Calls() []Call {
+	r.res.mu.Lock()
+	defer r.res.mu.Unlock()
+
+	return append([]Call(nil), r.res.calls...)
+}
+
+// CallMatcher matches a single call to the fake [testing.TB].
+type CallMatcher interface {
+	// MatchCall reports whether the call matches.
+	MatchCall(c Call) bool
+
+	// String describes the matcher for failure messages.
+	String() string
+}
+
+// ArgMatcher matches a single argument of a call.
+type ArgMatcher interface {
+	// MatchArg reports whether the argument matches.
+	MatchArg(arg any) bool
+
+	// String describes the matcher for failure messages.
+	String() string
+}
+
+type argMatcher struct {
+	desc  string
+	match func(any) bool
+}
+
+func (m argMatcher) MatchArg(arg any) bool { return m.match(arg) }
+func (m argMatcher) String() string        { return m.desc }
+
+// AnyArg matches any argument.
+func AnyArg() ArgMatcher {
+	return argMatcher{
+		desc:  "any",
+		match: func(any) bool { return true },
+	}
+}
+
+// ArgEquals matches arguments that are deeply equal to `v`.
+func ArgEquals(v any) ArgMatcher {
+	return argMatcher{
+		desc: formatArg(v),
+		match: func(arg any) bool {
+			return reflect.DeepEqual(arg, v)
+		},
+	}
+}
+
+// ArgContains matches arguments where the formatted argument contains `s`.
+func ArgContains(s string) ArgMatcher {
+	return argMatcher{
+		desc: fmt.Sprintf("contains %q", s),
+		match: func(arg any) bool {
+			return strings.Contains(fmt.Sprint(arg), s)
+		},
+	}
+}
+
+type callMatcher struct {
+	method string
+	args   []ArgMatcher // nil if args are not checked.
+}
+
+// CallTo matches calls to `method` with the given arguments.
+// Each argument is an [ArgMatcher], or a value that's matched using [ArgEquals].
+// If no arguments are specified, any arguments match.
+//
+// For methods with a format, such as Errorf, the format is the first argument.
+func CallTo(method string, args ...any) CallMatcher {
+	m := callMatcher{method: method}
+	for _, arg := range args {
+		am, ok := arg.(ArgMatcher)
+		if !ok {
+			am = ArgEquals(arg)
+		}
+		m.args = append(m.args, am)
+	}
+	return m
+}
+
+func (m callMatcher) MatchCall(c Call) bool {
+	if c.Method != m.method {
+		return false
+	}
+	if m.args == nil {
+		return true
+	}
+	if len(c.Args) != len(m.args) {
+		return false
+	}
+	for i, am := range m.args {
+		if !am.MatchArg(c.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m callMatcher) String() string {
+	if m.args == nil {
+		return m.method + "(...)"
+	}
+	return m.method + "(" + strings.Join(sliceutil.Map(m.args, ArgMatcher.String), ", ") + ")"
+}
+
+// ExpectCalls declares the exact sequence of calls a test is expected
+// to make to the fake [testing.TB], similar to a strict mock.
+// The test must be run with [Opts.RecordCalls].
+type ExpectCalls struct {
+	// Calls are the expected calls, in order. Each call must match
+	// a separate recorded call, and there must be no other calls.
+	Calls []CallMatcher
+
+	// Ignore are methods that are not checked, such as "Helper" or "Name".
+	Ignore []string
+}
+
+// Check verifies the calls made by the test against the expected calls,
+// reporting unexpected, missing and out of order calls as an error to `t`.
+func (e ExpectCalls) Check(t testing.TB, tr TestResult) {
+	t.Helper()
+
+	if !tr.res.opts.RecordCalls {
+		t.Errorf("cannot check calls, the test was not run with Opts.RecordCalls")
+		return
+	}
+
+	var calls []Call
+	for _, c := range tr.Calls() {
+		if !slices.Contains(e.Ignore, c.Method) {
+			calls = append(calls, c)
+		}
+	}
+
+	edits := diff.Align(len(e.Calls), len(calls), func(i, j int) bool {
+		return e.Calls[i].MatchCall(calls[j])
+	})
+
+	// Missing and unexpected calls that match each other are out of order.
+	var missing, unexpected []int
+	for _, edit := range edits {
+		switch edit.Op {
+		case diff.Delete:
+			missing = append(missing, edit.A)
+		case diff.Insert:
+			unexpected = append(unexpected, edit.B)
+		}
+	}
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return
+	}
+
+	outOfOrder := func(mi, ci int) string {
+		if e.Calls[mi].MatchCall(calls[ci]) {
+			return " (out of order)"
+		}
+		return ""
+	}
+
+	var buf strings.Builder
+	for _, edit := range edits {
+		switch edit.Op {
+		case diff.Equal:
+			fmt.Fprintf(&buf, "  matched: %v\n", callAt(calls[edit.B]))
+		case diff.Delete:
+			var note string
+			for _, ci := range unexpected {
+				if note = outOfOrder(edit.A, ci); note != "" {
+					break
+				}
+			}
+			fmt.Fprintf(&buf, "  missing: %v%v\n", e.Calls[edit.A], note)
+		case diff.Insert:
+			var note string
+			for _, mi := range missing {
+				if note = outOfOrder(mi, edit.B); note != "" {
+					break
+				}
+			}
+			fmt.Fprintf(&buf, "  unexpected: %v%v\n", callAt(calls[edit.B]), note)
+		}
+	}
+	t.Errorf("calls did not match:\n%v", buf.String())
+}
+
+// callAt formats the call with its caller location.
+func callAt(c Call) string {
+	if c.CallerFile == "" {
+		return c.String()
+	}
+	return fmt.Sprintf("%v at %v:%v", c, filepath.Base(c.CallerFile), c.CallerLine)
+}
@@ -0,0 +1,195 @@
+package faket
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/prashantv/faket/internal/sliceutil"
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestCalls(t *testing.T) {
+	tr := RunTestOpts(Opts{RecordCalls: true}, func(t testing.TB) {
+		t.Cleanup(func() {})
+		errorHelper(t, "failed")
+		t.Logf("got %v", 1)
+	})
+
+	calls := tr.Calls()
+	want.DeepEqual(t, "calls", sliceutil.Map(calls, Call.String), []string{
+		"Cleanup(func)",
+		"Helper()",
+		`Error("failed")`,
+		`Logf("got %v", 1)`,
+	})
+	want.Equal(t, "Helper CallerFunc", calls[1].CallerFunc, "github.com/prashantv/faket.errorHelper")
+	want.Equal(t, "Logf CallerFunc", calls[3].CallerFunc, "github.com/prashantv/faket.TestCalls.func1")
+	want.DeepEqual(t, "Helpers", tr.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+	tr.MustReportFromCaller(t)
+
+	noCalls := RunTest(func(t testing.TB) {
+		t.Log("log")
+	})
+	want.Equal(t, "Calls without RecordCalls", len(noCalls.Calls()), 0)
+}
+
+func TestCallsIndirect(t *testing.T) {
+	tr := RunTestOpts(Opts{
+		RecordCalls: true,
+		Hooks: []Hooks{{
+			OnLog: func(t testing.TB, l Log) {
+				if l.Message == "log" {
+					t.Log("hook saw", t.Name())
+				}
+			},
+		}},
+		Behaviors: Behaviors{
+			TempDir: func(t testing.TB) string {
+				t.Cleanup(func() {})
+				return "/tmp/" + t.Name()
+			},
+		},
+	}, func(t testing.TB) {
+		t.TempDir()
+		t.Log("log")
+		RunTest(func(t testing.TB) {
+			t.Log("replayed")
+		}).ReplayTo(t)
+	})
+
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log", "hook saw faket-no-name", "replayed"})
+	// ReplayTo calls Helper on the test's t, but replayed logs aren't recorded.
+	want.DeepEqual(t, "calls", sliceutil.Map(tr.Calls(), Call.String), []string{
+		"TempDir()",
+		`Log("log")`,
+		"Helper()",
+	})
+	ExpectCalls{
+		Calls:  []CallMatcher{CallTo("TempDir"), CallTo("Log", "log")},
+		Ignore: []string{"Helper"},
+	}.Check(t, tr)
+}
+
+func TestCallMatchers(t *testing.T) {
+	c := Call{Method: "Errorf", Args: []any{"got %v, want %v", 1, 2}}
+
+	tests := []struct {
+		matcher  CallMatcher
+		wantDesc string
+		want     bool
+	}{
+		{CallTo("Errorf"), "Errorf(...)", true},
+		{CallTo("Fatalf"), "Fatalf(...)", false},
+		{CallTo("Errorf", "got %v, want %v", 1, 2), `Errorf("got %v, want %v", 1, 2)`, true},
+		{CallTo("Errorf", ArgContains("want"), AnyArg(), ArgEquals(2)), `Errorf(contains "want", any, 2)`, true},
+		{CallTo("Errorf", AnyArg(), 1, 3), "Errorf(any, 1, 3)", false},
+		{CallTo("Errorf", AnyArg()), "Errorf(any)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantDesc, func(t *testing.T) {
+			want.Equal(t, "String", tt.matcher.String(), tt.wantDesc)
+			want.Equal(t, "MatchCall", tt.matcher.MatchCall(c), tt.want)
+		})
+	}
+}
+
+func TestExpectCalls(t *testing.T) {
+	opts := Opts{RecordCalls: true}
+	var line int // line before the calls to Helper and Error.
+	helperTR := RunTestOpts(opts, func(t testing.TB) {
+		_, _, line, _ = runtime.Caller(0)
+		t.Helper()
+		t.Error("failed")
+	})
+	helperAt := fmt.Sprintf("calls_test.go:%v", line+1)
+	errorAt := fmt.Sprintf("calls_test.go:%v", line+2)
+	fatalTR := RunTestOpts(opts, func(t testing.TB) {
+		t.Helper()
+		t.Fatal("fatal")
+	})
+
+	tests := []struct {
+		name       string
+		tr         TestResult
+		expect     ExpectCalls
+		wantErrors []string
+	}{
+		{
+			name: "match",
+			tr:   helperTR,
+			expect: ExpectCalls{
+				Calls: []CallMatcher{CallTo("Helper"), CallTo("Error", "failed")},
+			},
+		},
+		{
+			name: "ignore",
+			tr:   helperTR,
+			expect: ExpectCalls{
+				Calls:  []CallMatcher{CallTo("Error")},
+				Ignore: []string{"Helper"},
+			},
+		},
+		{
+			name: "missing",
+			tr:   helperTR,
+			expect: ExpectCalls{
+				Calls: []CallMatcher{CallTo("Helper"), CallTo("Error"), CallTo("Log")},
+			},
+			wantErrors: []string{
+				"calls did not match:\n" +
+					"  matched: Helper() at " + helperAt + "\n" +
+					`  matched: Error("failed") at ` + errorAt + "\n" +
+					"  missing: Log(...)\n",
+			},
+		},
+		{
+			name: "unexpected",
+			tr:   fatalTR,
+			expect: ExpectCalls{
+				Calls: []CallMatcher{CallTo("Helper"), CallTo("Errorf")},
+			},
+			wantErrors: []string{
+				"  missing: Errorf(...)\n",
+				`  unexpected: Fatal("fatal") at calls_test.go:`,
+			},
+		},
+		{
+			name: "out of order",
+			tr:   helperTR,
+			expect: ExpectCalls{
+				Calls: []CallMatcher{CallTo("Error"), CallTo("Helper")},
+			},
+			wantErrors: []string{
+				"  missing: Error(...) (out of order)\n",
+				`  unexpected: Error("failed") at ` + errorAt + " (out of order)",
+			},
+		},
+		{
+			name:       "not recorded",
+			tr:         RunTest(func(t testing.TB) {}),
+			expect:     ExpectCalls{},
+			wantErrors: []string{"the test was not run with Opts.RecordCalls"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTest(func(t testing.TB) {
+				tt.expect.Check(t, tt.tr)
+			})
+
+			want.Equal(t, "Failed", tr.Failed(), len(tt.wantErrors) > 0)
+			if len(tt.wantErrors) == 0 {
+				return
+			}
+
+			logs := tr.Logs()
+			want.Equal(t, "error count", len(logs), 1)
+			for _, wantErr := range tt.wantErrors {
+				want.Contains(t, "Message", logs[0].Message, wantErr)
+			}
+		})
+	}
+}
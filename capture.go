@@ -0,0 +1,112 @@
+package faket
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Captures from concurrent fakes can start and stop in any order, so active
+// captures are tracked in the order they started. The last capture is the
+// current redirect, and each capture restores the output of the capture
+// before it.
+var (
+	capturesMu sync.Mutex
+	captures   []*outputCapture // protected by capturesMu
+)
+
+// outputCapture redirects os.Stdout, os.Stderr and the standard logger
+// while a test runs, see Opts.CaptureOutput.
+type outputCapture struct {
+	prevStdout *os.File
+	prevStderr *os.File
+	prevLog    io.Writer
+
+	stdoutW *os.File
+	stderrW *os.File
+
+	wg     sync.WaitGroup
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+// startCapture starts capturing output, which must be stopped using stop.
+func startCapture() (*outputCapture, error) {
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		stdoutR.Close() //nolint:errcheck // closing unused pipe.
+		stdoutW.Close() //nolint:errcheck // closing unused pipe.
+		return nil, err
+	}
+
+	c := &outputCapture{
+		stdoutW: stdoutW,
+		stderrW: stderrW,
+	}
+	c.copy(&c.stdout, stdoutR)
+	c.copy(&c.stderr, stderrR)
+
+	capturesMu.Lock()
+	defer capturesMu.Unlock()
+
+	c.prevStdout = os.Stdout
+	c.prevStderr = os.Stderr
+	c.prevLog = log.Writer()
+	captures = append(captures, c)
+
+	os.Stdout = stdoutW
+	os.Stderr = stderrW
+	log.SetOutput(stderrW)
+	return c, nil
+}
+
+func (c *outputCapture) copy(dst *bytes.Buffer, r *os.File) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer r.Close() //nolint:errcheck // read-only pipe.
+
+		io.Copy(dst, r) //nolint:errcheck // the pipe is only closed by stop.
+	}()
+}
+
+// stop restores the original output, and returns the captured output.
+// If a later capture is still active, it restores this capture's original
+// output instead.
+func (c *outputCapture) stop() (stdout, stderr []byte) {
+	c.unlink()
+
+	c.stdoutW.Close() //nolint:errcheck // the reader sees EOF on close.
+	c.stderrW.Close() //nolint:errcheck // the reader sees EOF on close.
+	c.wg.Wait()
+
+	return c.stdout.Bytes(), c.stderr.Bytes()
+}
+
+// unlink removes the capture from the active captures, restoring the output
+// it replaced, or passing the output to the next capture to restore.
+func (c *outputCapture) unlink() {
+	capturesMu.Lock()
+	defer capturesMu.Unlock()
+
+	i := slices.Index(captures, c)
+	if i == len(captures)-1 {
+		os.Stdout = c.prevStdout
+		os.Stderr = c.prevStderr
+		log.SetOutput(c.prevLog)
+	} else {
+		next := captures[i+1]
+		next.prevStdout = c.prevStdout
+		next.prevStderr = c.prevStderr
+		next.prevLog = c.prevLog
+	}
+	captures = slices.Delete(captures, i, i+1)
+}
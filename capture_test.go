@@ -0,0 +1,132 @@
+package faket
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+// printHelper is a helper that reports using fmt rather than t.
+func printHelper(_ testing.TB, msg string) {
+	fmt.Println(msg)
+}
+
+func TestCaptureOutput(t *testing.T) {
+	stdout, stderr, logOut := os.Stdout, os.Stderr, log.Writer()
+
+	tr := RunTestOpts(Opts{CaptureOutput: true}, func(t testing.TB) {
+		t.Cleanup(func() {
+			fmt.Println("in cleanup")
+		})
+		t.Log("log")
+		printHelper(t, "stdout")
+		fmt.Fprintln(os.Stderr, "stderr")
+		log.New(log.Writer(), "", 0).Print("standard logger")
+		t.Error("failed")
+	})
+
+	want.Equal(t, "Outcome", tr.Outcome(), OutcomeFail)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log", "failed"})
+	want.Equal(t, "Stdout", tr.Stdout(), "stdout\nin cleanup\n")
+	want.Equal(t, "Stderr", tr.Stderr(), "stderr\nstandard logger\n")
+
+	want.Equal(t, "restored os.Stdout", os.Stdout, stdout)
+	want.Equal(t, "restored os.Stderr", os.Stderr, stderr)
+	want.Equal(t, "restored log output", log.Writer(), logOut)
+}
+
+func TestCaptureOutputConcurrent(t *testing.T) {
+	stdout, stderr, logOut := os.Stdout, os.Stderr, log.Writer()
+
+	// captureRun starts a fake that prints msg, and blocks until release is closed.
+	captureRun := func(msg string, release <-chan struct{}) *Run {
+		started := make(chan struct{})
+		r := StartOpts(Opts{CaptureOutput: true}, func(t testing.TB) {
+			fmt.Println(msg)
+			close(started)
+			<-release
+		})
+		<-started
+		return r
+	}
+
+	// The first capture stops before the second, which must restore
+	// the original output rather than the first capture's closed pipe.
+	releaseFirst, releaseSecond := make(chan struct{}), make(chan struct{})
+	first := captureRun("first", releaseFirst)
+	second := captureRun("second", releaseSecond)
+
+	close(releaseFirst)
+	firstTR := first.Wait()
+	want.Equal(t, "os.Stdout during second capture", os.Stdout == stdout, false)
+
+	close(releaseSecond)
+	secondTR := second.Wait()
+
+	want.Equal(t, "first Stdout", firstTR.Stdout(), "first\n")
+	want.Equal(t, "second Stdout", secondTR.Stdout(), "second\n")
+
+	want.Equal(t, "restored os.Stdout", os.Stdout, stdout)
+	want.Equal(t, "restored os.Stderr", os.Stderr, stderr)
+	want.Equal(t, "restored log output", log.Writer(), logOut)
+	_, err := os.Stdout.Write(nil)
+	want.NoErr(t, err)
+}
+
+func TestCaptureOutputDisabled(t *testing.T) {
+	tr := RunTest(func(t testing.TB) {
+		t.Log("log")
+	})
+	want.Equal(t, "Stdout", tr.Stdout(), "")
+	want.Equal(t, "Stderr", tr.Stderr(), "")
+}
+
+func TestMustNotPrint(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Opts
+		fn      func(t testing.TB)
+		wantErr string
+	}{
+		{
+			name: "uses t",
+			opts: Opts{CaptureOutput: true},
+			fn: func(t testing.TB) {
+				errorHelper(t, "failed")
+			},
+		},
+		{
+			name: "prints",
+			opts: Opts{CaptureOutput: true},
+			fn: func(t testing.TB) {
+				printHelper(t, "diff:\n-want\n+got")
+				log.Print("logged")
+			},
+			wantErr: "test wrote output outside of t:\nstdout:\n    diff:\n    -want\n    +got\nstderr:\n    ",
+		},
+		{
+			name:    "not captured",
+			opts:    Opts{},
+			fn:      func(t testing.TB) {},
+			wantErr: "the test was not run with Opts.CaptureOutput",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := RunTestOpts(tt.opts, tt.fn)
+			tr := RunTest(func(t testing.TB) {
+				inner.MustNotPrint(t)
+			})
+
+			if tt.wantErr == "" {
+				tr.MustPass(t)
+				return
+			}
+			tr.MustFail(t, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package faket
+
+import "testing"
+
+// Case is a single test case for a helper, see [Cases].
+type Case[In any] struct {
+	// Name is used as the name of the subtest.
+	Name string
+
+	// In is the input passed to the helper.
+	In In
+
+	// Want is the expected result of running the helper.
+	Want Expect
+}
+
+// Cases is a table of test cases for a helper that takes an input of type In.
+type Cases[In any] []Case[In]
+
+// Run runs each case in a subtest of `t`, where the helper is run with the
+// case's input using [RunTest], and the result is checked against [Case.Want].
+func (cs Cases[In]) Run(t *testing.T, helper func(testing.TB, In)) {
+	t.Helper()
+
+	for _, c := range cs {
+		t.Run(c.Name, func(t *testing.T) {
+			c.run(t, helper)
+		})
+	}
+}
+
+func (c Case[In]) run(t testing.TB, helper func(testing.TB, In)) {
+	t.Helper()
+
+	tr := RunTest(func(t testing.TB) {
+		helper(t, c.In)
+	})
+	c.Want.Check(t, tr)
+}
@@ -0,0 +1,50 @@
+package faket
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func hasPrefix(t testing.TB, in [2]string) {
+	t.Helper()
+
+	if !strings.HasPrefix(in[0], in[1]) {
+		t.Errorf("%q does not have prefix %q", in[0], in[1])
+	}
+}
+
+func TestCases(t *testing.T) {
+	Cases[[2]string]{
+		{
+			Name: "has prefix",
+			In:   [2]string{"foobar", "foo"},
+			Want: Expect{Outcome: OutcomePass, NoExtraLogs: true},
+		},
+		{
+			Name: "missing prefix",
+			In:   [2]string{"foobar", "bar"},
+			Want: Expect{
+				Outcome: OutcomeFail,
+				Logs:    []LogMatcher{LogEquals(`"foobar" does not have prefix "bar"`)},
+				Helpers: []string{"faket.hasPrefix"},
+			},
+		},
+	}.Run(t, hasPrefix)
+}
+
+func TestCaseMismatch(t *testing.T) {
+	c := Case[[2]string]{
+		Name: "missing prefix",
+		In:   [2]string{"foobar", "bar"},
+		Want: Expect{Outcome: OutcomePass},
+	}
+
+	tr := RunTest(func(t testing.TB) {
+		c.run(t, hasPrefix)
+	})
+	want.Equal(t, "Failed", tr.Failed(), true)
+	want.Contains(t, "Message", tr.Logs().String(), "cases_test.go")
+	want.Contains(t, "Message", tr.Logs().String(), "unexpected outcome, got FAIL, want PASS")
+}
@@ -0,0 +1,118 @@
+package faket
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// Only a single isolated fake can modify the process environment
+// or working directory at a time, see EnvIsolation.
+var (
+	envMu    sync.Mutex
+	envCond  = sync.NewCond(&envMu)
+	envOwner *fakeTB // protected by envMu
+)
+
+// running maps the ID of a goroutine running the test of an isolated fake
+// to the fake, used to find the parent of fakes started within a test.
+var (
+	runningMu sync.Mutex
+	running   = make(map[uint64]*fakeTB) // protected by runningMu
+)
+
+// acquireEnv marks the fake as modifying the process until the test
+// completes, handling any concurrent fake based on EnvIsolation.
+//
+// A fake that's started by the test of the current owner can't wait for the
+// owner to complete, as the owner is waiting for it, so it always fails.
+func (tb *fakeTB) acquireEnv(method string) {
+	if tb.opts.EnvIsolation == EnvIsolationNone {
+		return
+	}
+
+	var conflict string
+	func() {
+		envMu.Lock()
+		defer envMu.Unlock()
+
+		for envOwner != nil && envOwner != tb {
+			if tb.hasAncestor(envOwner) {
+				conflict = "an enclosing test"
+				return
+			}
+			if tb.opts.EnvIsolation == EnvIsolationDetect {
+				conflict = "a concurrent test"
+				return
+			}
+			envCond.Wait()
+		}
+		envOwner = tb
+	}()
+
+	if conflict != "" {
+		tb.Fatalf("%v: cannot modify the process while %v is using Setenv or Chdir", method, conflict)
+	}
+}
+
+// releaseEnv allows other isolated fakes to modify the process.
+// It's called after cleanups have restored any changes.
+func (tb *fakeTB) releaseEnv() {
+	envMu.Lock()
+	defer envMu.Unlock()
+
+	if envOwner == tb {
+		envOwner = nil
+		envCond.Broadcast()
+	}
+}
+
+// hasAncestor reports whether other started this fake, directly
+// or using a nested fake.
+func (tb *fakeTB) hasAncestor(other *fakeTB) bool {
+	for p := tb.parent; p != nil; p = p.parent {
+		if p == other {
+			return true
+		}
+	}
+	return false
+}
+
+// trackRunning records that the current goroutine is running the test
+// of the fake, until the returned function is called.
+func trackRunning(tb *fakeTB) (untrack func()) {
+	id := goroutineID()
+
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	running[id] = tb
+	return func() {
+		runningMu.Lock()
+		defer runningMu.Unlock()
+
+		delete(running, id)
+	}
+}
+
+// runningFake returns the fake whose test is running in the current goroutine.
+// Fakes started from other goroutines created by the test are not found.
+func runningFake() *fakeTB {
+	id := goroutineID()
+
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	return running[id]
+}
+
+// goroutineID returns the ID of the current goroutine, which is
+// the first line of its stack trace, "goroutine <id> [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	stack := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	id, _, _ := bytes.Cut(stack, []byte(" "))
+	n, _ := strconv.ParseUint(string(id), 10, 64)
+	return n
+}
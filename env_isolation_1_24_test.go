@@ -0,0 +1,14 @@
+//go:build go1.24
+
+package faket
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEnvIsolationDetectChdir(t *testing.T) {
+	testEnvIsolationDetect(t, "Chdir", func(t testing.TB) {
+		t.Chdir(os.TempDir())
+	})
+}
@@ -0,0 +1,141 @@
+package faket
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+const envIsolationKey = "FAKET_ENV_ISOLATION_TEST"
+
+// runEnvOwner runs a fake that calls Setenv, and blocks until release is closed.
+func runEnvOwner(opts Opts, release <-chan struct{}) (started <-chan struct{}, result <-chan TestResult) {
+	startedCh := make(chan struct{})
+	resultCh := make(chan TestResult, 1)
+	go func() {
+		resultCh <- RunTestOpts(opts, func(t testing.TB) {
+			t.Setenv(envIsolationKey, "owner")
+			close(startedCh)
+			<-release
+		})
+	}()
+	return startedCh, resultCh
+}
+
+func TestEnvIsolationDetect(t *testing.T) {
+	testEnvIsolationDetect(t, "Setenv", func(t testing.TB) {
+		t.Setenv(envIsolationKey, "concurrent")
+	})
+}
+
+// testEnvIsolationDetect verifies that fn fails when run concurrently
+// with another fake that has modified the environment.
+func testEnvIsolationDetect(t *testing.T, method string, fn func(t testing.TB)) {
+	opts := Opts{EnvIsolation: EnvIsolationDetect}
+
+	release := make(chan struct{})
+	started, result := runEnvOwner(opts, release)
+	<-started
+
+	tr := RunTestOpts(opts, fn)
+	tr.MustFail(t, method+": cannot modify the process while a concurrent test is using Setenv or Chdir")
+	want.Equal(t, "env value", os.Getenv(envIsolationKey), "owner")
+
+	close(release)
+	(<-result).MustPass(t)
+
+	// Once the owner completes, other fakes can modify the process.
+	RunTestOpts(opts, fn).MustPass(t)
+}
+
+func TestEnvIsolationSerialize(t *testing.T) {
+	opts := Opts{EnvIsolation: EnvIsolationSerialize}
+
+	release := make(chan struct{})
+	started, result := runEnvOwner(opts, release)
+	<-started
+
+	concurrentResult := make(chan TestResult, 1)
+	go func() {
+		concurrentResult <- RunTestOpts(opts, func(t testing.TB) {
+			t.Setenv(envIsolationKey, "concurrent")
+		})
+	}()
+
+	select {
+	case <-concurrentResult:
+		t.Fatalf("concurrent Setenv should block until owner completes")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	(<-result).MustPass(t)
+	(<-concurrentResult).MustPass(t)
+
+	_, ok := os.LookupEnv(envIsolationKey)
+	want.Equal(t, "env set after tests", ok, false)
+}
+
+func TestEnvIsolationNone(t *testing.T) {
+	release := make(chan struct{})
+	started, result := runEnvOwner(Opts{}, release)
+	<-started
+
+	RunTest(func(t testing.TB) {
+		t.Setenv(envIsolationKey, "concurrent")
+	}).MustPass(t)
+
+	close(release)
+	(<-result).MustPass(t)
+}
+
+func TestEnvIsolationNested(t *testing.T) {
+	tests := []struct {
+		name      string
+		isolation EnvIsolation
+	}{
+		{name: "detect", isolation: EnvIsolationDetect},
+		{name: "serialize", isolation: EnvIsolationSerialize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Opts{EnvIsolation: tt.isolation}
+
+			result := make(chan TestResult, 1)
+			go func() {
+				result <- RunTestOpts(opts, func(t testing.TB) {
+					t.Setenv(envIsolationKey, "outer")
+
+					// The nested fake is started using another isolated fake.
+					RunTestOpts(opts, func(testing.TB) {
+						RunTestOpts(opts, func(t testing.TB) {
+							t.Setenv(envIsolationKey, "nested")
+						}).MustFail(t, "Setenv: cannot modify the process while an enclosing test is using Setenv or Chdir")
+					}).MustPass(t)
+
+					want.Equal(t, "env value", os.Getenv(envIsolationKey), "outer")
+				})
+			}()
+
+			select {
+			case tr := <-result:
+				tr.MustPass(t)
+			case <-time.After(time.Second):
+				t.Fatalf("nested Setenv did not complete")
+			}
+
+			// The outer test's cleanups run in the same goroutine, and can run nested fakes.
+			RunTestOpts(opts, func(t testing.TB) {
+				t.Setenv(envIsolationKey, "outer")
+				t.Cleanup(func() {
+					RunTestOpts(opts, func(t testing.TB) {
+						t.Setenv(envIsolationKey, "nested")
+					}).MustFail(t, "enclosing test")
+				})
+			}).MustPass(t)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package cmpt compares running a test function under faket against
+// running it with a real [testing.T] in `go test`.
+//
+// The real run uses a subprocess of the current test binary, so results
+// don't need to be recorded ahead of time. This is useful to verify that
+// wrappers of [testing.TB] behave the same as the real thing. The subprocess
+// output is parsed using `go tool test2json`, so the go command must be available.
+package cmpt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket"
+	"github.com/prashantv/faket/internal/diff"
+	"github.com/prashantv/faket/internal/subproc"
+)
+
+const (
+	// runEnv is set in the subprocess to the test name and call number to run.
+	runEnv = "FAKET_CMPT_RUN"
+
+	// subtestName is the name of the subtest that runs the test function
+	// in the subprocess.
+	subtestName = "faket-cmpt"
+)
+
+// Opts are options for comparing faket to a real test run.
+type Opts struct {
+	// Normalizer is applied to the output of both runs before comparing,
+	// see [faket.Normalizer].
+	Normalizer faket.Normalizer
+}
+
+// Compare runs `f` using [faket.RunTest], and in a subtest of the current test
+// in a subprocess of the test binary, and reports any differences in the
+// outcome or logs as errors to `t`.
+//
+// The subprocess re-runs the current test from the start, until Compare
+// is called, so any code before Compare must be safe to run in a subprocess.
+// The rest of the test is skipped in the subprocess.
+// Since the real test crashes on panics, only logs before the panic are compared.
+func Compare(t *testing.T, f func(testing.TB)) {
+	t.Helper()
+
+	CompareOpts(t, Opts{}, f)
+}
+
+// CompareOpts is the same as Compare, but supports options for customizing comparisons.
+func CompareOpts(t *testing.T, opts Opts, f func(testing.TB)) {
+	t.Helper()
+
+	call, _ := calls.Next(t)
+	if run, ok := os.LookupEnv(runEnv); ok {
+		// In the subprocess, only run the specific call to Compare,
+		// then skip the rest of the test so code after it only runs once.
+		if run == call {
+			t.Run(subtestName, func(t *testing.T) {
+				f(t)
+			})
+			t.SkipNow()
+		}
+		return
+	}
+
+	actual, err := runReal(t.Name(), call)
+	if err != nil {
+		t.Fatalf("failed to run test in subprocess: %v", err)
+	}
+
+	compareResults(t, opts, actual, faket.RunTest(f))
+}
+
+// calls identifies each call to Compare within a test.
+var calls subproc.Calls
+
+// realResult is the result of running the test function with a real testing.T.
+type realResult struct {
+	name       string
+	outcome    faket.Outcome
+	transcript string // logs and status lines, as printed by `go test -v`.
+}
+
+// runReal runs the call in a subprocess of the test binary, using test2json
+// to attribute output to tests.
+func runReal(testName, call string) (realResult, error) {
+	subtest := testName + "/" + subtestName
+	args := append([]string{"tool", "test2json", os.Args[0], "-test.v=test2json"}, subproc.Args(subtest)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(goCmd(), args...)
+	cmd.Env = append(os.Environ(), runEnv+"="+call)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// A failing or panicking test will exit with a non-zero exit code,
+	// so only errors from failing to start the process are reported.
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); err != nil && !errors.As(err, &exitErr) {
+		return realResult{}, err
+	}
+
+	res, err := parseEvents(&stdout, subtest)
+	if err != nil {
+		return realResult{}, fmt.Errorf("%v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+	}
+	return res, nil
+}
+
+// goCmd returns the go command from the toolchain that built the test binary,
+// falling back to the go command in $PATH.
+func goCmd() string {
+	if goroot := runtime.GOROOT(); goroot != "" {
+		cmd := filepath.Join(goroot, "bin", "go")
+		if _, err := os.Stat(cmd); err == nil {
+			return cmd
+		}
+	}
+	return "go"
+}
+
+// testEvent is an event output by `go tool test2json`.
+type testEvent struct {
+	Action string
+	Test   string
+	Output string
+}
+
+// parseEvents extracts the result for the given test from test2json events.
+func parseEvents(r io.Reader, testName string) (realResult, error) {
+	res := realResult{name: testName}
+
+	var transcript strings.Builder
+	dec := json.NewDecoder(r)
+	for {
+		var ev testEvent
+		if err := dec.Decode(&ev); err == io.EOF {
+			break
+		} else if err != nil {
+			return realResult{}, fmt.Errorf("failed to decode test2json event: %v", err)
+		}
+
+		if ev.Test != testName {
+			continue
+		}
+
+		switch ev.Action {
+		case "output":
+			// Logs are indented, unlike the test's framing lines, such as "=== RUN".
+			if strings.HasPrefix(ev.Output, "    ") {
+				transcript.WriteString(ev.Output)
+			}
+		case "pass":
+			res.outcome = faket.OutcomePass
+		case "fail":
+			res.outcome = faket.OutcomeFail
+		case "skip":
+			res.outcome = faket.OutcomeSkip
+		}
+	}
+
+	if res.outcome == 0 {
+		return realResult{}, fmt.Errorf("missing result for test %q", testName)
+	}
+
+	res.transcript = fmt.Sprintf("=== RUN   %s\n%s--- %v: %s (0.00s)\n", testName, transcript.String(), res.outcome, testName)
+	return res, nil
+}
+
+// compareResults reports differences between the real and faket results.
+func compareResults(t testing.TB, opts Opts, actual realResult, tr faket.TestResult) {
+	t.Helper()
+
+	var logs faket.Logs
+	for _, l := range tr.Logs() {
+		// The real test crashes on panics, and the panic is not a log.
+		if l.Kind != faket.LogKindPanic {
+			logs = append(logs, l)
+		}
+	}
+
+	got := logs.Format(faket.FormatOpts{
+		Framing: faket.FramingVerbose,
+		Name:    actual.name,
+		Outcome: tr.Outcome(),
+	})
+	got = opts.Normalizer.Normalize(got)
+	want := opts.Normalizer.Normalize(actual.transcript)
+
+	if got != want {
+		t.Errorf("faket differs from go test (-go test +faket):\n%s", diff.Lines(want, got))
+	}
+}
@@ -0,0 +1,180 @@
+package cmpt
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket"
+	"github.com/prashantv/faket/internal/subproc"
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(testing.TB)
+	}{
+		{
+			name: "logs",
+			fn: func(t testing.TB) {
+				t.Log("log", 1)
+				t.Logf("multi\nline %v", 2)
+			},
+		},
+		{
+			name: "error",
+			fn: func(t testing.TB) {
+				t.Error("error")
+				t.Log("after error")
+			},
+		},
+		{
+			name: "fatal in cleanup",
+			fn: func(t testing.TB) {
+				t.Cleanup(func() {
+					t.Fatal("fatal in cleanup")
+				})
+				t.Log("before cleanup")
+			},
+		},
+		{
+			name: "helper",
+			fn: func(t testing.TB) {
+				logHelper(t, "in helper")
+			},
+		},
+		{
+			name: "skip",
+			fn: func(t testing.TB) {
+				t.Skip("skipped")
+				t.Log("unreachable")
+			},
+		},
+		{
+			name: "panic",
+			fn: func(t testing.TB) {
+				t.Log("before panic")
+				panic("boom")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Compare(t, tt.fn)
+		})
+	}
+}
+
+func TestCompare_MultipleCalls(t *testing.T) {
+	Compare(t, func(t testing.TB) {
+		t.Log("first call")
+	})
+	Compare(t, func(t testing.TB) {
+		t.Error("second call")
+	})
+}
+
+func TestCompare_Count(t *testing.T) {
+	// Each run of the test must identify calls from the start.
+	args := append(subproc.Args("TestCompare_MultipleCalls"), "-test.count=2", "-test.v")
+	out, err := exec.Command(os.Args[0], args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("test failed with -count=2: %v\n%s", err, out)
+	}
+}
+
+func TestCompare_SkipsRestOfTest(t *testing.T) {
+	const markerEnv = "FAKET_CMPT_TEST_MARKER"
+
+	// The marker is set by the parent, and inherited by the subprocess.
+	marker := os.Getenv(markerEnv)
+	if marker == "" {
+		marker = filepath.Join(t.TempDir(), "after-compare")
+		t.Setenv(markerEnv, marker)
+	}
+
+	Compare(t, func(t testing.TB) {
+		t.Log("compared")
+	})
+
+	if _, ok := os.LookupEnv(runEnv); ok {
+		if err := os.WriteFile(marker, nil, 0o666); err != nil {
+			t.Fatalf("failed to write marker: %v", err)
+		}
+		return
+	}
+
+	_, err := os.Stat(marker)
+	want.Equal(t, "code after Compare ran in subprocess", err == nil, false)
+}
+
+func logHelper(t testing.TB, msg string) {
+	t.Helper()
+	t.Log(msg)
+}
+
+func TestParseEvents(t *testing.T) {
+	// The real test interleaves output with another test, and logs text
+	// that looks like framing lines.
+	const events = `{"Action":"start"}
+{"Action":"run","Test":"TestA"}
+{"Action":"output","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"output","Test":"TestA","Output":"    a_test.go:6: top\n"}
+{"Action":"run","Test":"TestA/faket-cmpt"}
+{"Action":"output","Test":"TestA/faket-cmpt","Output":"=== RUN   TestA/faket-cmpt\n","OutputType":"frame"}
+{"Action":"output","Test":"TestA/faket-cmpt","Output":"    a_test.go:8: in sub\n"}
+{"Action":"output","Test":"TestA/faket-cmpt","Output":"        === RUN   TestA/other\n"}
+{"Action":"output","Test":"TestA/other","Output":"    a_test.go:10: other err\n","OutputType":"error"}
+{"Action":"output","Test":"TestA/faket-cmpt","Output":"    a_test.go:12:     --- PASS: TestA/faket-cmpt (0.00s)\n","OutputType":"error"}
+{"Action":"output","Test":"TestA/faket-cmpt","Output":"--- FAIL: TestA/faket-cmpt (0.12s)\n","OutputType":"frame"}
+{"Action":"fail","Test":"TestA/faket-cmpt","Elapsed":0.12}
+{"Action":"fail","Test":"TestA/other","Elapsed":0}
+{"Action":"output","Test":"TestA","Output":"--- FAIL: TestA (0.00s)\n"}
+{"Action":"fail","Test":"TestA","Elapsed":0.12}
+{"Action":"output","Output":"FAIL\n"}
+{"Action":"fail","Elapsed":0.12}
+`
+	res, err := parseEvents(strings.NewReader(events), "TestA/faket-cmpt")
+	want.NoErr(t, err)
+	want.Equal(t, "outcome", res.outcome, faket.OutcomeFail)
+	want.Equal(t, "transcript", res.transcript, `=== RUN   TestA/faket-cmpt
+    a_test.go:8: in sub
+        === RUN   TestA/other
+    a_test.go:12:     --- PASS: TestA/faket-cmpt (0.00s)
+--- FAIL: TestA/faket-cmpt (0.00s)
+`)
+
+	_, err = parseEvents(strings.NewReader(events), "TestB")
+	want.Contains(t, "missing result", err.Error(), `missing result for test "TestB"`)
+
+	_, err = parseEvents(strings.NewReader("=== RUN   TestA\n"), "TestA")
+	want.Contains(t, "invalid event", err.Error(), "failed to decode test2json event")
+}
+
+func TestCompareResults(t *testing.T) {
+	actual := realResult{
+		name:    "TestA/faket-cmpt",
+		outcome: faket.OutcomePass,
+		transcript: "=== RUN   TestA/faket-cmpt\n" +
+			"    cmpt_test.go:0: real log\n" +
+			"--- PASS: TestA/faket-cmpt (0.00s)\n",
+	}
+
+	tr := faket.RunTest(func(t testing.TB) {
+		compareResults(t, Opts{Normalizer: faket.Normalizer{LineNumbers: true}}, actual, faket.RunTest(func(t testing.TB) {
+			t.Error("fake log")
+		}))
+	})
+	want.Equal(t, "Failed", tr.Failed(), true)
+	want.Contains(t, "diff", tr.Logs()[0].Message, `faket differs from go test (-go test +faket):
+ === RUN   TestA/faket-cmpt
+-    cmpt_test.go:0: real log
+---- PASS: TestA/faket-cmpt (0.00s)
++    cmpt_test.go:0: fake log
++--- FAIL: TestA/faket-cmpt (0.00s)
+`)
+}
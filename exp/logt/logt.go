@@ -0,0 +1,235 @@
+// Package logt routes logs from [log/slog] and [log.Logger] to a [testing.TB],
+// attributing each log to the code that logged it.
+//
+// Logs written after the test completes are discarded, so loggers used
+// by background goroutines don't panic after the test ends.
+//
+// Before Go 1.25, the testing package doesn't support logging with a different
+// location, so logs to a real [testing.TB] are attributed to the logging package.
+// Logs to a fake from faket are always attributed to the code that logged.
+package logt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prashantv/faket/internal/tblog"
+)
+
+// NewWriter returns an [io.Writer] that logs each line written to `t`.
+// Multiple lines in a single write are logged together, and a partial line
+// is logged once the line is completed, or when the test completes.
+//
+// The writer is intended to be used with [log.New], or to capture
+// output from helpers that print to an [io.Writer].
+func NewWriter(t testing.TB) io.Writer {
+	w := &writer{t: t}
+	t.Cleanup(w.close)
+	return w
+}
+
+type writer struct {
+	t testing.TB
+
+	mu        sync.Mutex // protects all of the below fields.
+	done      bool
+	buf       []byte        // partial line.
+	bufCaller runtime.Frame // caller of the first write to buf.
+}
+
+// Write logs complete lines in p to `t`, and never returns an error.
+func (w *writer) Write(p []byte) (int, error) {
+	caller := logCaller()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.done {
+		return len(p), nil
+	}
+
+	if len(w.buf) == 0 {
+		w.bufCaller = caller
+	}
+	w.buf = append(w.buf, p...)
+	if i := bytes.LastIndexByte(w.buf, '\n'); i >= 0 {
+		logAt(w.t, w.bufCaller, string(w.buf[:i]))
+		w.buf = append([]byte(nil), w.buf[i+1:]...)
+		w.bufCaller = caller
+	}
+	return len(p), nil
+}
+
+// close logs any partial line, and discards any later writes.
+func (w *writer) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		logAt(w.t, w.bufCaller, string(w.buf))
+		w.buf = nil
+	}
+	w.done = true
+}
+
+// NewHandler returns a [slog.Handler] that logs each record to `t`,
+// formatted using [slog.NewTextHandler] with the given options.
+//
+// Use [slog.HandlerOptions].ReplaceAttr to remove the time from logs.
+func NewHandler(t testing.TB, opts *slog.HandlerOptions) slog.Handler {
+	s := &handlerState{t: t}
+	t.Cleanup(s.close)
+	return &handler{
+		state: s,
+		text:  slog.NewTextHandler(&s.buf, opts),
+	}
+}
+
+// handlerState is shared by a handler and the handlers derived from it.
+type handlerState struct {
+	t testing.TB
+
+	mu   sync.Mutex // protects all of the below fields.
+	done bool
+	buf  bytes.Buffer // written by text handlers.
+}
+
+func (s *handlerState) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done = true
+}
+
+type handler struct {
+	state *handlerState
+	text  slog.Handler
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.text.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	caller := logCaller()
+	if r.PC != 0 {
+		caller, _ = runtime.CallersFrames([]uintptr{r.PC}).Next()
+	}
+
+	s := h.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done {
+		return nil
+	}
+
+	s.buf.Reset()
+	if err := h.text.Handle(ctx, r); err != nil {
+		return err
+	}
+	logAt(s.t, caller, strings.TrimSuffix(s.buf.String(), "\n"))
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{state: h.state, text: h.text.WithAttrs(attrs)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{state: h.state, text: h.text.WithGroup(name)}
+}
+
+func logAt(t testing.TB, caller runtime.Frame, msg string) {
+	t.Helper()
+
+	tblog.LogTo(t, tblog.Entry{
+		Message: msg,
+		File:    caller.File,
+		Line:    caller.Line,
+		Func:    caller.Function,
+	})
+}
+
+// logCaller returns the first caller outside of the standard library and
+// this package, which is typically the code that called a logger.
+// If there's no such caller, it returns the first caller outside this package.
+func logCaller() runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var outside runtime.Frame
+	for {
+		f, more := frames.Next()
+		pkg := funcPackage(f.Function)
+		if pkg != thisPackage {
+			if !isStdlib(pkg, f.File, modulePaths(), runtime.GOROOT()) {
+				return f
+			}
+			if outside.PC == 0 {
+				outside = f
+			}
+		}
+		if !more {
+			return outside
+		}
+	}
+}
+
+const thisPackage = "github.com/prashantv/faket/exp/logt"
+
+// funcPackage returns the package path of a fully qualified function name,
+// such as "log" for "log.(*Logger).output".
+func funcPackage(fn string) string {
+	lastSlash := strings.LastIndexByte(fn, '/')
+	if dot := strings.IndexByte(fn[lastSlash+1:], '.'); dot >= 0 {
+		return fn[:lastSlash+1+dot]
+	}
+	return fn
+}
+
+// modulePaths returns the paths of the modules in the binary.
+var modulePaths = sync.OnceValue(func() []string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	paths := []string{bi.Main.Path}
+	for _, dep := range bi.Deps {
+		paths = append(paths, dep.Path)
+	}
+	return paths
+})
+
+// isStdlib reports whether the package pkg, with a function in file,
+// is in the standard library. Packages in one of the binary's modules
+// are not, otherwise the file must be in goroot. If goroot is unknown
+// (e.g., when built using -trimpath), the standard library is assumed
+// to be packages without a domain in the first path element.
+func isStdlib(pkg, file string, modules []string, goroot string) bool {
+	// External test packages have a "_test" suffix that's not in the module path.
+	pkg = strings.TrimSuffix(pkg, "_test")
+	for _, m := range modules {
+		if m != "" && (pkg == m || strings.HasPrefix(pkg, m+"/")) {
+			return false
+		}
+	}
+
+	if goroot != "" {
+		rel, err := filepath.Rel(filepath.Join(goroot, "src"), file)
+		return err == nil && filepath.IsLocal(rel)
+	}
+
+	first, _, _ := strings.Cut(pkg, "/")
+	return pkg != "main" && !strings.Contains(first, ".")
+}
@@ -0,0 +1,26 @@
+//go:build go1.25
+
+package logt_test
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/prashantv/faket/exp/cmpt"
+	"github.com/prashantv/faket/exp/logt"
+)
+
+func TestMatchesRealLogs(t *testing.T) {
+	cmpt.Compare(t, func(t testing.TB) {
+		logger := log.New(logt.NewWriter(t), "", 0)
+		logger.Printf("multi\nline %v", 1)
+
+		w := logt.NewWriter(t)
+		fmt.Fprint(w, "partial")
+
+		slogger := slog.New(logt.NewHandler(t, &slog.HandlerOptions{ReplaceAttr: noTime}))
+		slogger.Info("info", "k", "v")
+	})
+}
@@ -0,0 +1,143 @@
+package logt_test
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/prashantv/faket"
+	"github.com/prashantv/faket/exp/logt"
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestWriter(t *testing.T) {
+	tr := faket.RunTest(func(t testing.TB) {
+		logger := log.New(logt.NewWriter(t), "", 0)
+		logger.Print("log")
+		logger.Printf("multi\nline %v", 1)
+
+		w := logt.NewWriter(t)
+		fmt.Fprint(w, "partial")
+		fmt.Fprint(w, " line\nunterminated")
+	})
+
+	tr.MustPass(t)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{
+		"log",
+		"multi\nline 1",
+		"partial line",
+		"unterminated",
+	})
+	for _, l := range tr.Logs() {
+		want.Equal(t, "CallerFunc", l.CallerFunc, "github.com/prashantv/faket/exp/logt_test.TestWriter.func1")
+	}
+}
+
+func TestWriterAfterTest(t *testing.T) {
+	var w io.Writer
+	t.Run("test", func(t *testing.T) {
+		w = logt.NewWriter(t)
+		fmt.Fprintln(w, "during test")
+	})
+
+	// Writing to a completed test would panic if it was logged.
+	n, err := fmt.Fprint(w, "after test\n")
+	want.NoErr(t, err)
+	want.Equal(t, "written", n, 11)
+
+	var fakeW io.Writer
+	tr := faket.RunTest(func(t testing.TB) {
+		fakeW = logt.NewWriter(t)
+	})
+	fmt.Fprintln(fakeW, "after test")
+	want.Equal(t, "logs", len(tr.Logs()), 0)
+}
+
+// noTime removes the time from logs.
+func noTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestHandler(t *testing.T) {
+	var afterTest *slog.Logger
+	tr := faket.RunTest(func(t testing.TB) {
+		logger := slog.New(logt.NewHandler(t, &slog.HandlerOptions{ReplaceAttr: noTime}))
+		logger.Debug("filtered")
+		logger.Info("info", "k", "v")
+		logger.With("a", 1).WithGroup("g").Warn("multi\nline", "b", 2)
+		afterTest = logger
+	})
+	afterTest.Info("after test")
+
+	tr.MustPass(t)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{
+		"level=INFO msg=info k=v",
+		`level=WARN msg="multi\nline" a=1 g.b=2`,
+	})
+	for _, l := range tr.Logs() {
+		want.Equal(t, "CallerFunc", l.CallerFunc, "github.com/prashantv/faket/exp/logt_test.TestHandler.func1")
+	}
+}
+
+func TestHandlerSlogtest(t *testing.T) {
+	var results []map[string]any
+	tr := faket.RunTestOpts(faket.Opts{
+		Hooks: []faket.Hooks{{
+			OnLog: func(ft testing.TB, l faket.Log) {
+				// Only parse logs from the handler, not failures.
+				if l.Kind == faket.LogKindLog {
+					results = append(results, parseText(ft, l.Message))
+				}
+			},
+		}},
+	}, func(ft testing.TB) {
+		err := slogtest.TestHandler(logt.NewHandler(ft, nil), func() []map[string]any {
+			return results
+		})
+		want.NoErr(ft, err)
+	})
+	tr.MustPass(t)
+}
+
+// parseText parses a line from slog.TextHandler into a map,
+// with a nested map for each group.
+func parseText(t testing.TB, line string) map[string]any {
+	m := make(map[string]any)
+	for line != "" {
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			t.Fatalf("missing = in %q", line)
+		}
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			want.NoErr(t, err)
+			value, err = strconv.Unquote(quoted)
+			want.NoErr(t, err)
+			rest = rest[len(quoted):]
+		} else {
+			value, rest, _ = strings.Cut(rest, " ")
+		}
+		line = strings.TrimPrefix(rest, " ")
+
+		groups := strings.Split(key, ".")
+		cur := m
+		for _, g := range groups[:len(groups)-1] {
+			if _, ok := cur[g]; !ok {
+				cur[g] = make(map[string]any)
+			}
+			cur = cur[g].(map[string]any)
+		}
+		cur[groups[len(groups)-1]] = value
+	}
+	return m
+}
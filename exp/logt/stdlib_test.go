@@ -0,0 +1,89 @@
+package logt
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestIsStdlib(t *testing.T) {
+	const goroot = "/usr/local/go"
+
+	tests := []struct {
+		name    string
+		pkg     string
+		file    string
+		modules []string
+		goroot  string
+		want    bool
+	}{
+		{
+			name:    "stdlib",
+			pkg:     "log",
+			file:    "/usr/local/go/src/log/log.go",
+			modules: []string{"myapp"},
+			goroot:  goroot,
+			want:    true,
+		},
+		{
+			name:    "dotless main module",
+			pkg:     "myapp",
+			file:    "/src/myapp/app_test.go",
+			modules: []string{"myapp"},
+			goroot:  goroot,
+			want:    false,
+		},
+		{
+			name:    "dotless main module external test",
+			pkg:     "myapp_test",
+			file:    "/src/myapp/app_test.go",
+			modules: []string{"myapp"},
+			goroot:  goroot,
+			want:    false,
+		},
+		{
+			name:    "dotless nested module package",
+			pkg:     "internal/svc/handler",
+			file:    "/src/svc/handler/handler.go",
+			modules: []string{"internal/svc"},
+			goroot:  goroot,
+			want:    false,
+		},
+		{
+			name:    "module prefix is not a path prefix",
+			pkg:     "myapplication",
+			file:    "/usr/local/go/src/myapplication/x.go",
+			modules: []string{"myapp"},
+			goroot:  goroot,
+			want:    true,
+		},
+		{
+			name:   "no build info outside goroot",
+			pkg:    "myapp",
+			file:   "/src/myapp/app_test.go",
+			goroot: goroot,
+			want:   false,
+		},
+		{
+			name:    "trimpath stdlib",
+			pkg:     "log",
+			file:    "log/log.go",
+			modules: []string{"myapp"},
+			want:    true,
+		},
+		{
+			name:    "trimpath dotless module",
+			pkg:     "myapp",
+			file:    "myapp/app_test.go",
+			modules: []string{"myapp"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isStdlib(tt.pkg, tt.file, tt.modules, tt.goroot)
+			want.Equal(t, "isStdlib", got, tt.want)
+		})
+	}
+}
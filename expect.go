@@ -0,0 +1,72 @@
+package faket
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Expect declares the expected result of a test,
+// so test cases can declare expectations as data.
+type Expect struct {
+	// Outcome is the expected outcome of the test.
+	// If unset, the outcome is not checked.
+	Outcome Outcome
+
+	// Logs are matched in order against the logs, see [TestResult.MustFailWith].
+	Logs []LogMatcher
+
+	// NoExtraLogs reports logs that aren't matched by Logs.
+	NoExtraLogs bool
+
+	// Helpers are functions expected to call [testing.TB].Helper.
+	// Names can omit a prefix of the package path, similar to [LogFromFunc].
+	Helpers []string
+
+	// PanicContains is expected in the panic value of a test that panicked.
+	// If empty, the test must not panic.
+	PanicContains string
+}
+
+// Check verifies the test result against the expectations,
+// reporting all mismatches as errors to `t`.
+func (e Expect) Check(t testing.TB, tr TestResult) {
+	t.Helper()
+
+	if got := tr.Outcome(); e.Outcome != 0 && got != e.Outcome {
+		t.Errorf("unexpected outcome, got %v, want %v", got, e.Outcome)
+	}
+
+	logs := tr.Logs()
+	if m := matchLogs(logs, e.Logs, MatchOpts{NoOtherLogs: e.NoExtraLogs}); !m.ok() {
+		t.Errorf("logs did not match:\n%vlogs:\n%v", m, logs)
+	}
+
+	helpers := tr.Helpers()
+	for _, want := range e.Helpers {
+		if !containsFunc(helpers, want) {
+			t.Errorf("missing helper %v, got helpers:\n%v", want, indentLines(strings.Join(helpers, "\n")+"\n"))
+		}
+	}
+
+	switch {
+	case e.PanicContains == "" && tr.Panicked():
+		t.Errorf("unexpected panic: %v", tr.res.recovered)
+	case e.PanicContains != "" && !tr.Panicked():
+		t.Errorf("test did not panic, but expected panic containing %q", e.PanicContains)
+	case e.PanicContains != "":
+		if rec := fmt.Sprint(tr.res.recovered); !strings.Contains(rec, e.PanicContains) {
+			t.Errorf("panic string doesn't contain %q. got:\n%v%s",
+				e.PanicContains, rec, closestDiff(e.PanicContains, []string{rec}))
+		}
+	}
+}
+
+func containsFunc(funcs []string, fn string) bool {
+	for _, f := range funcs {
+		if funcMatches(f, fn) {
+			return true
+		}
+	}
+	return false
+}
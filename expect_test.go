@@ -0,0 +1,98 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestExpect(t *testing.T) {
+	fnTR := RunTest(func(t testing.TB) {
+		t.Log("starting")
+		errorHelper(t, "failed")
+	})
+	panicTR := RunTest(func(t testing.TB) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name       string
+		tr         TestResult
+		expect     Expect
+		wantErrors []string
+	}{
+		{
+			name:   "no expectations",
+			tr:     fnTR,
+			expect: Expect{},
+		},
+		{
+			name: "all match",
+			tr:   fnTR,
+			expect: Expect{
+				Outcome:     OutcomeFail,
+				Logs:        []LogMatcher{LogEquals("starting"), LogContains("fail")},
+				NoExtraLogs: true,
+				Helpers:     []string{"errorHelper", "faket.errorHelper"},
+			},
+		},
+		{
+			name: "all mismatches are reported",
+			tr:   fnTR,
+			expect: Expect{
+				Outcome:       OutcomePass,
+				Logs:          []LogMatcher{LogContains("other")},
+				Helpers:       []string{"errorNotHelper"},
+				PanicContains: "boom",
+			},
+			wantErrors: []string{
+				"unexpected outcome, got FAIL, want PASS",
+				`missing: contains "other"`,
+				"missing helper errorNotHelper, got helpers:\n    github.com/prashantv/faket.errorHelper",
+				`test did not panic, but expected panic containing "boom"`,
+			},
+		},
+		{
+			name: "extra logs",
+			tr:   fnTR,
+			expect: Expect{
+				Logs:        []LogMatcher{LogContains("fail")},
+				NoExtraLogs: true,
+			},
+			wantErrors: []string{"unexpected: expect_test.go:"},
+		},
+		{
+			name:   "panic matches",
+			tr:     panicTR,
+			expect: Expect{Outcome: OutcomeFail, PanicContains: "boom"},
+		},
+		{
+			name:       "panic mismatch",
+			tr:         panicTR,
+			expect:     Expect{PanicContains: "bang"},
+			wantErrors: []string{`panic string doesn't contain "bang"`},
+		},
+		{
+			name:       "unexpected panic",
+			tr:         panicTR,
+			expect:     Expect{Outcome: OutcomeFail},
+			wantErrors: []string{"unexpected panic: boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTest(func(t testing.TB) {
+				tt.expect.Check(t, tt.tr)
+			})
+
+			logs := tr.Logs()
+			want.Equal(t, "Failed", tr.Failed(), len(tt.wantErrors) > 0)
+			want.Equal(t, "error count", len(logs), len(tt.wantErrors))
+			for i, l := range logs {
+				want.Equal(t, "Kind", l.Kind, LogKindError)
+				want.Contains(t, "Message", l.Message, tt.wantErrors[i])
+			}
+		})
+	}
+}
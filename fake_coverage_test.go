@@ -76,13 +76,21 @@ func findTypeMethods(path, typeName string) ([]string, error) {
 }
 
 func receiverName(f *ast.Field) string {
-	switch recv := f.Type.(type) {
+	return typeName(f.Type)
+}
+
+func typeName(expr ast.Expr) string {
+	switch recv := expr.(type) {
 	case *ast.Ident:
 		return recv.Name
 	case *ast.StarExpr:
-		if ident, ok := recv.X.(*ast.Ident); ok {
-			return ident.Name
-		}
+		return typeName(recv.X)
+	case *ast.IndexExpr:
+		// Generic type with a single type parameter.
+		return typeName(recv.X)
+	case *ast.IndexListExpr:
+		// Generic type with multiple type parameters.
+		return typeName(recv.X)
 	}
-	panic(fmt.Errorf("cannot parse type name from receiver: %#v", f.Type))
+	panic(fmt.Errorf("cannot parse type name from receiver: %#v", expr))
 }
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"sync"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/prashantv/faket/internal/sliceutil"
 )
@@ -21,13 +24,32 @@ type fakeTB struct {
 	testing.TB
 
 	ctx       context.Context
-	cancelCtx context.CancelFunc
+	cancelCtx context.CancelCauseFunc
+
+	// testFn is the function passed to RunTest.
+	testFn uintptr
+
+	// parent is the fake whose test started this fake, if any.
+	parent *fakeTB
+
+	opts Opts
 
 	mu sync.Mutex // protects all of the below fields.
 
 	cleanups []cleanup
 	helpers  map[uintptr]struct{}
 	logs     []logEntry
+	tempDirs []string
+
+	// TempDir parent directory state, see nextTempDir.
+	tempDirParent string
+	tempDirErr    error
+	tempDirSeq    int
+
+	sideEffects []SideEffect
+
+	// only recorded when using Opts.RecordCalls.
+	calls []Call
 
 	completed chan struct{}
 	failed    bool
@@ -41,11 +63,28 @@ type fakeTB struct {
 	// panic metadata
 	recovered      any
 	recoverCallers []uintptr
+
+	// number of calls for each operation that supports faults.
+	faultCalls map[FaultOp]int
+
+	// only set when using Opts.CaptureOutput, or for stderr of isolated tests.
+	stdout []byte
+	stderr []byte
+
+	// set if output is captured by the caller rather than startTest.
+	externalCapture bool
+
+	// only set for results of isolated tests, see RunIsolated.
+	helperNames []string
+	exited      bool
+	exitCode    int
 }
 
 type logEntry struct {
+	resolved       *Log      // set for logs from another process
 	callers        []uintptr // callers[0] is the tb function that logged
 	cleanupCallers []uintptr // for logs within a cleanup function
+	kind           LogKind
 	entry          string
 }
 
@@ -57,62 +96,146 @@ type cleanup struct {
 // RunTest runs the given test using a fake [testing.TB] and returns
 // the result of running the test.
 func RunTest(testFn func(t testing.TB)) TestResult {
-	tb := newFakeTB()
+	return runTest(newFakeTB(Opts{}), testFn)
+}
+
+// RunTestOpts is the same as RunTest, but with options to customize
+// the fake [testing.TB].
+func RunTestOpts(opts Opts, testFn func(t testing.TB)) TestResult {
+	return runTest(newFakeTB(opts), testFn)
+}
+
+func runTest(tb *fakeTB, testFn func(t testing.TB)) TestResult {
+	startTest(tb, testFn)
+
+	<-tb.completed
+	return TestResult{tb}
+}
+
+// startTest runs the test in a new goroutine, and returns without waiting
+// for the test to complete.
+func startTest(tb *fakeTB, testFn func(t testing.TB)) {
+	// testFn is already set when testFn wraps the user's function, see RunReporter.
+	if tb.testFn == 0 {
+		tb.testFn = reflect.ValueOf(testFn).Pointer()
+	}
+	// Fakes are only tracked to detect nesting when they use EnvIsolation.
+	track := tb.opts.EnvIsolation != EnvIsolationNone
+	if track {
+		tb.parent = runningFake()
+	}
 
 	go func() {
 		defer close(tb.completed)
 		defer tb.checkPanic()
+		defer tb.releaseEnv()
+		if track {
+			defer trackRunning(tb)()
+		}
+
+		if tb.opts.Audit {
+			dirs := absDirs(tb.opts.AuditDirs)
+			before := takeSnapshot(dirs)
+			defer func() {
+				effects := before.sideEffects(takeSnapshot(dirs))
+
+				tb.mu.Lock()
+				defer tb.mu.Unlock()
+
+				tb.sideEffects = effects
+			}()
+		}
+
+		if tb.opts.CaptureOutput && !tb.externalCapture {
+			c, err := startCapture()
+			if err != nil {
+				tb.Fatalf("faket: cannot capture output: %v", err)
+			}
+			defer func() {
+				stdout, stderr := c.stop()
+
+				tb.mu.Lock()
+				defer tb.mu.Unlock()
+
+				tb.stdout = stdout
+				tb.stderr = stderr
+			}()
+		}
+
 		defer tb.runCleanups()
 
-		testFn(tb)
+		testFn(tb.testTB())
 	}()
-
-	<-tb.completed
-	return TestResult{tb}
 }
 
-func newFakeTB() *fakeTB {
-	ctx, cancel := context.WithCancel(context.Background())
+func newFakeTB(opts Opts) *fakeTB {
+	parentCtx := context.Background()
+	if opts.Behaviors.Context != nil {
+		parentCtx = opts.Behaviors.Context()
+	}
+
+	ctx, cancel := context.WithCancelCause(parentCtx)
 	return &fakeTB{
+		opts:      opts,
 		ctx:       ctx,
 		cancelCtx: cancel,
 		completed: make(chan struct{}),
 		helpers:   make(map[uintptr]struct{}),
+
+		faultCalls: make(map[FaultOp]int),
 	}
 }
 
 func (tb *fakeTB) Name() string {
+	if f := tb.opts.Behaviors.Name; f != nil {
+		return f()
+	}
 	return "faket-no-name"
 }
 
 // Cleaup and post-test methods.
 
 func (tb *fakeTB) Cleanup(f func()) {
-	callerPCs := getCallers(skipSelf)
+	tb.addCleanup(getCallers(skipSelf), f)
+}
 
+func (tb *fakeTB) addCleanup(callers []uintptr, f func()) {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
 	tb.cleanups = append(tb.cleanups, cleanup{
-		callers: callerPCs,
+		callers: callers,
 		fn:      f,
 	})
 }
 
 func (tb *fakeTB) checkPanic() {
 	if r := recover(); r != nil {
-		tb.mu.Lock()
-		defer tb.mu.Unlock()
+		callers := getCallers(skipSelf)
+		first := func() bool {
+			tb.mu.Lock()
+			defer tb.mu.Unlock()
 
-		tb.panicked = true
-		tb.recovered = r
-		tb.recoverCallers = getCallers(skipSelf)
-		tb.logfLocked(tb.recoverCallers, "panic: %v", r)
+			wasFailed := tb.failed || tb.panicked
+			tb.panicked = true
+			tb.recovered = r
+			tb.recoverCallers = callers
+			return !wasFailed
+		}()
+		tb.log(callers, LogKindPanic, fmt.Sprintf("panic: %v", r))
+
+		if first {
+			tb.runHooks(func(h Hooks) {
+				if h.OnFail != nil {
+					h.OnFail(tb.indirectTB())
+				}
+			})
+		}
 	}
 }
 
 func (tb *fakeTB) runCleanups() {
-	tb.cancelCtx()
+	tb.cancelCtx(nil)
 
 	// Set cleanupRoot so log callers can use cleanup's callers.
 	if self := getCaller(withSelf); self != 0 {
@@ -163,6 +286,12 @@ func (tb *fakeTB) runCleanups() {
 			tb.curCleanupPC = c.callers
 		}()
 
+		tb.runHooks(func(h Hooks) {
+			if h.OnCleanup != nil {
+				h.OnCleanup(tb.indirectTB())
+			}
+		})
+
 		c.fn()
 	}
 }
@@ -170,80 +299,117 @@ func (tb *fakeTB) runCleanups() {
 // Logging methods
 
 func (tb *fakeTB) Error(args ...interface{}) {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
-
-	tb.logLocked(getCallers(withSelf), args...)
-	tb.failLocked()
+	tb.log(getCallers(withSelf), LogKindError, sprintln(args...))
+	tb.Fail()
 }
 
 func (tb *fakeTB) Errorf(format string, args ...interface{}) {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
-
-	tb.logfLocked(getCallers(withSelf), format, args...)
-	tb.failLocked()
+	tb.log(getCallers(withSelf), LogKindError, fmt.Sprintf(format, args...))
+	tb.Fail()
 }
 
 func (tb *fakeTB) Fatal(args ...interface{}) {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
-
-	tb.logLocked(getCallers(withSelf), args...)
-	tb.failNowLocked()
+	tb.log(getCallers(withSelf), LogKindFatal, sprintln(args...))
+	tb.FailNow()
 }
 
 func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
-	tb.logfLocked(getCallers(withSelf), format, args...)
+	tb.log(getCallers(withSelf), LogKindFatal, fmt.Sprintf(format, args...))
 	tb.FailNow()
 }
 
 func (tb *fakeTB) Log(args ...interface{}) {
-	tb.logLocked(getCallers(withSelf), args...)
+	tb.log(getCallers(withSelf), LogKindLog, sprintln(args...))
 }
 
 func (tb *fakeTB) Logf(format string, args ...interface{}) {
-	tb.logfLocked(getCallers(withSelf), format, args...)
+	tb.log(getCallers(withSelf), LogKindLog, fmt.Sprintf(format, args...))
 }
 
 func (tb *fakeTB) Skip(args ...interface{}) {
-	tb.logLocked(getCallers(withSelf), args...)
-	tb.skipNowLocked()
+	tb.log(getCallers(withSelf), LogKindSkip, sprintln(args...))
+	tb.SkipNow()
 }
 
 func (tb *fakeTB) Skipf(format string, args ...interface{}) {
-	tb.logfLocked(getCallers(withSelf), format, args...)
+	tb.log(getCallers(withSelf), LogKindSkip, fmt.Sprintf(format, args...))
 	tb.SkipNow()
 }
 
-func (tb *fakeTB) logfLocked(callers []uintptr, format string, args ...interface{}) {
-	formatted := fmt.Sprintf(format, args...)
-	tb.logs = append(tb.logs, logEntry{
-		callers: callers,
-		entry:   formatted,
+// sprintln formats args the same as the testing package, using Sprintln,
+// but drops the trailing newline as we store an array of lines.
+func sprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+// log records a log entry, and returns the entry.
+func (tb *fakeTB) log(callers []uintptr, kind LogKind, msg string) logEntry {
+	return tb.appendLog(func() logEntry {
+		return logEntry{
+			callers:        callers,
+			cleanupCallers: tb.curCleanupPC,
+			kind:           kind,
+			entry:          msg,
+		}
+	})
+}
+
+// logResolved records a log that was resolved by another fake.
+func (tb *fakeTB) logResolved(l Log) {
+	tb.appendLog(func() logEntry {
+		return logEntry{
+			resolved: &l,
+			kind:     l.Kind,
+			entry:    l.Message,
+		}
 	})
 }
 
-func (tb *fakeTB) logLocked(callers []uintptr, args ...interface{}) {
-	// Log args are formatted using Sprintln in the testing package
-	// but we drop the trailing newline as we store an array of lines.
-	formatted := fmt.Sprintln(args...)
-	formatted = strings.TrimSuffix(formatted, "\n")
+// appendLog appends the entry created while holding the lock,
+// and passes the resolved log to any OnLog hooks.
+// Logs are only resolved when there are hooks, as resolving is expensive.
+// Hooks are called without holding the lock, so they can use the fakeTB.
+func (tb *fakeTB) appendLog(newEntry func() logEntry) logEntry {
+	var l Log
+	e := func() logEntry {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
 
-	tb.logs = append(tb.logs, logEntry{
-		callers:        callers,
-		cleanupCallers: tb.curCleanupPC,
-		entry:          formatted,
+		e := newEntry()
+		tb.logs = append(tb.logs, e)
+		if len(tb.opts.Hooks) > 0 {
+			l = tb.toLogLocked(e)
+		}
+		return e
+	}()
+
+	tb.runHooks(func(h Hooks) {
+		if h.OnLog != nil {
+			h.OnLog(tb.indirectTB(), l)
+		}
 	})
+	return e
 }
 
 // Fail-related methods.
 
 func (tb *fakeTB) Fail() {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
+	first := func() bool {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
+
+		wasFailed := tb.failed || tb.panicked
+		tb.failLocked()
+		return !wasFailed
+	}()
 
-	tb.failLocked()
+	if first {
+		tb.runHooks(func(h Hooks) {
+			if h.OnFail != nil {
+				h.OnFail(tb.indirectTB())
+			}
+		})
+	}
 }
 
 func (tb *fakeTB) Failed() bool {
@@ -254,14 +420,7 @@ func (tb *fakeTB) Failed() bool {
 }
 
 func (tb *fakeTB) FailNow() {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
-
-	tb.failNowLocked()
-}
-
-func (tb *fakeTB) failNowLocked() {
-	tb.failLocked()
+	tb.Fail()
 	runtime.Goexit()
 }
 
@@ -284,18 +443,25 @@ func (tb *fakeTB) done() bool {
 // Skip-related methods.
 
 func (tb *fakeTB) SkipNow() {
-	tb.mu.Lock()
-	defer tb.mu.Unlock()
+	func() {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
 
-	tb.skipNowLocked()
-}
+		tb.skipped = true
+	}()
 
-func (tb *fakeTB) skipNowLocked() {
-	tb.skipped = true
+	tb.runHooks(func(h Hooks) {
+		if h.OnSkip != nil {
+			h.OnSkip(tb.indirectTB())
+		}
+	})
 	runtime.Goexit()
 }
 
 func (tb *fakeTB) Skipped() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
 	return tb.skipped
 }
 
@@ -309,11 +475,15 @@ func (tb *fakeTB) Helper() {
 		return
 	}
 
+	tb.markHelper(callerPC)
+}
+
+func (tb *fakeTB) markHelper(pc uintptr) {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
-	if _, ok := tb.helpers[callerPC]; !ok {
-		tb.helpers[callerPC] = struct{}{}
+	if _, ok := tb.helpers[pc]; !ok {
+		tb.helpers[pc] = struct{}{}
 	}
 }
 
@@ -321,22 +491,47 @@ func (tb *fakeTB) helperFuncs() []string {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
+	return tb.helperFuncsLocked()
+}
+
+func (tb *fakeTB) helperFuncsLocked() []string {
 	funcs := make([]string, 0, len(tb.helpers))
 	for pc := range tb.helpers {
 		if fn := pcToFunction(pc); fn != "" {
 			funcs = append(funcs, fn)
 		}
 	}
-	return funcs
+	return append(funcs, tb.helperNames...)
 }
 
-// Convert internal logEntry (using PCs) to exported Log (no PCs).
+// resolvedLogs returns all logs converted to exported Logs.
+func (tb *fakeTB) resolvedLogs() Logs {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return sliceutil.Map(tb.logs, tb.toLogLocked)
+}
+
+// toLog converts e to an exported Log.
 func (tb *fakeTB) toLog(e logEntry) Log {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return tb.toLogLocked(e)
+}
+
+// Convert internal logEntry (using PCs) to exported Log (no PCs).
+func (tb *fakeTB) toLogLocked(e logEntry) Log {
+	if e.resolved != nil {
+		return *e.resolved
+	}
+
 	l := Log{
 		Message: e.entry,
+		Kind:    e.kind,
 	}
 
-	skipSet := sliceutil.ToSet(tb.helperFuncs())
+	skipSet := sliceutil.ToSet(tb.helperFuncsLocked())
 	// When a defer is triggered by a panic, it's added to the trace
 	// but panic is not shown as a log caller.
 	skipSet["runtime.gopanic"] = struct{}{}
@@ -376,9 +571,20 @@ func (tb *fakeTB) toLog(e logEntry) Log {
 // Helpers which aren't core to testing.TB
 
 func (tb *fakeTB) Setenv(key, value string) {
+	if f := tb.opts.Behaviors.Setenv; f != nil {
+		f(tb.indirectTB(), key, value)
+		return
+	}
+
+	tb.acquireEnv("Setenv")
+
 	prevVal, prevSet := os.LookupEnv(key)
 
-	if err := os.Setenv(key, value); err != nil {
+	err := tb.fault(FaultSetenv)
+	if err == nil {
+		err = os.Setenv(key, value)
+	}
+	if err != nil {
 		// Match the error from stdlib.
 		tb.Fatalf("cannot set environment variable: %v", err)
 	}
@@ -390,6 +596,9 @@ func (tb *fakeTB) Setenv(key, value string) {
 		} else {
 			err = os.Setenv(key, prevVal)
 		}
+		if fErr := tb.fault(FaultSetenvRestore); fErr != nil {
+			err = fErr
+		}
 		if err != nil {
 			// This error is ignored by stdlib, but let's report it.
 			tb.Fatalf("cannot revert environment variable: %v", err)
@@ -397,46 +606,157 @@ func (tb *fakeTB) Setenv(key, value string) {
 	})
 }
 
+// TempDir records each directory, whether it's created by Behaviors.TempDir
+// or the default implementation.
 func (tb *fakeTB) TempDir() string {
-	pattern := strings.Map(func(r rune) rune {
-		// Similar to stdlib, but with more restricted allowed set.
-		const allowed = " -_"
-		if '0' <= r && r <= '9' ||
-			'a' <= r && r <= 'z' ||
-			'A' <= r && r <= 'Z' {
-			return r
-		}
-		if strings.ContainsRune(allowed, r) {
-			return r
-		}
-		return -1
-	}, tb.Name())
+	var dir string
+	if f := tb.opts.Behaviors.TempDir; f != nil {
+		dir = f(tb.indirectTB())
+	} else {
+		dir = tb.defaultTempDir()
+	}
+
+	func() {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
+
+		tb.tempDirs = append(tb.tempDirs, dir)
+	}()
+
+	return dir
+}
 
-	d, err := os.MkdirTemp("", pattern)
+// defaultTempDir matches the stdlib layout, creating a single parent
+// directory for the test, with numbered directories for each call.
+func (tb *fakeTB) defaultTempDir() string {
+	parent, seq, created, err := tb.nextTempDir()
+	if created {
+		tb.Cleanup(func() {
+			tb.mu.Lock()
+			parent := tb.tempDirParent
+			tb.mu.Unlock()
+
+			err := os.RemoveAll(parent)
+			if fErr := tb.fault(FaultTempDirCleanup); fErr != nil {
+				err = fErr
+			}
+			if err != nil {
+				tb.Errorf("TempDir RemoveAll cleanup: %v", err)
+			}
+		})
+	}
 	if err != nil {
 		tb.Fatalf("TempDir: %v", err)
 	}
 
-	tb.Cleanup(func() {
-		if err := os.RemoveAll(d); err != nil {
-			tb.Errorf("TempDir RemoveAll cleanup: %v", err)
+	dir := fmt.Sprintf("%s%c%03d", parent, os.PathSeparator, seq)
+	err = tb.fault(FaultTempDir)
+	if err == nil {
+		err = os.Mkdir(dir, 0o777)
+	}
+	if err != nil {
+		tb.Fatalf("TempDir: %v", err)
+	}
+	return dir
+}
+
+// nextTempDir returns the parent directory for TempDir, creating it if it
+// doesn't exist, and the sequence number of the next directory.
+// Similar to stdlib, failing to create the parent fails all later calls.
+func (tb *fakeTB) nextTempDir() (parent string, seq int, created bool, _ error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	nonExistent := tb.tempDirParent == ""
+	if !nonExistent {
+		_, err := os.Stat(tb.tempDirParent)
+		nonExistent = os.IsNotExist(err)
+		if err != nil && !nonExistent {
+			return "", 0, false, err
 		}
-	})
+	}
 
-	return d
+	if nonExistent {
+		tb.tempDirParent, tb.tempDirErr = os.MkdirTemp(os.Getenv("GOTMPDIR"), tempDirPattern(tb.Name()))
+		created = tb.tempDirErr == nil
+	}
+	if tb.tempDirErr != nil {
+		return "", 0, false, tb.tempDirErr
+	}
+
+	tb.tempDirSeq++
+	return tb.tempDirParent, tb.tempDirSeq, created, nil
+}
+
+// tempDirPattern returns the pattern for the TempDir parent directory,
+// using the same limits and allowed characters as stdlib.
+func tempDirPattern(name string) string {
+	// Limit length of file names on disk.
+	// Invalid runes from slicing are dropped by strings.Map below.
+	name = name[:min(len(name), 64)]
+
+	return strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf {
+			const allowed = "!#$%&()+,-.=@^_{}~ "
+			if '0' <= r && r <= '9' ||
+				'a' <= r && r <= 'z' ||
+				'A' <= r && r <= 'Z' {
+				return r
+			}
+			if strings.ContainsRune(allowed, r) {
+				return r
+			}
+		} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return -1
+	}, name)
 }
 
 func (tb *fakeTB) Chdir(dir string) {
+	if f := tb.opts.Behaviors.Chdir; f != nil {
+		f(tb.indirectTB(), dir)
+		return
+	}
+
+	tb.acquireEnv("Chdir")
+
 	oldWd, err := os.Open(".")
 	if err != nil {
 		// Match stdlib error.
 		tb.Fatal(err)
 	}
+	if err := tb.fault(FaultChdir); err != nil {
+		oldWd.Close() //nolint:errcheck // best-effort close to avoid leaks.
+		tb.Fatal(err)
+	}
 	if err := os.Chdir(dir); err != nil {
+		oldWd.Close() //nolint:errcheck // best-effort close to avoid leaks.
 		// Match stdlib error.
 		tb.Fatal(err)
 	}
 
+	restore := func() {
+		defer oldWd.Close() //nolint:errcheck // best-effort close to avoid leaks.
+		err := oldWd.Chdir()
+		if fErr := tb.fault(FaultChdirRestore); fErr != nil {
+			err = fErr
+		}
+		if err != nil {
+			// Note: The stdlib implementation panics here, but we avoid panics.
+			tb.Errorf("ChDir failed to ChDir to original directory: %v", err)
+		}
+	}
+
+	// Similar to stdlib, the restore is registered after setting PWD, so it
+	// runs first. If setting PWD stops the test, restore immediately.
+	registered := false
+	defer func() {
+		if !registered {
+			restore()
+		}
+	}()
+
 	// Similar to stdlib, on POSIX, set PWD.
 	switch runtime.GOOS {
 	case "windows", "plan9":
@@ -451,13 +771,8 @@ func (tb *fakeTB) Chdir(dir string) {
 		tb.Setenv("PWD", dir)
 	}
 
-	tb.Cleanup(func() {
-		defer oldWd.Close() //nolint:errcheck // best-effort close to avoid leaks.
-		if err := oldWd.Chdir(); err != nil {
-			// Note: The stdlib implementation panics here, but we avoid panics.
-			tb.Errorf("ChDir failed to ChDir to original directory: %v", err)
-		}
-	})
+	registered = true
+	tb.Cleanup(restore)
 }
 
 func (tb *fakeTB) Context() context.Context {
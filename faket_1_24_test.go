@@ -0,0 +1,43 @@
+//go:build go1.24
+
+package faket
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestFakeT_ChdirCleanupOrder(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("PWD is only set on POSIX")
+	}
+
+	wd, err := os.Getwd()
+	want.NoErr(t, err)
+	pwd := os.Getenv("PWD")
+	dir := t.TempDir()
+
+	// Matching stdlib, the working directory is restored before PWD.
+	type state struct{ wd, pwd string }
+	var states []state
+	RunTestOpts(Opts{
+		Hooks: []Hooks{{
+			OnCleanup: func(testing.TB) {
+				cur, err := os.Getwd()
+				want.NoErr(t, err)
+				states = append(states, state{cur, os.Getenv("PWD")})
+			},
+		}},
+	}, func(t testing.TB) {
+		t.Chdir(dir)
+	}).MustPass(t)
+
+	want.DeepEqual(t, "states before each cleanup", states, []state{
+		{wd: dir, pwd: dir},
+		{wd: wd, pwd: dir},
+	})
+	want.Equal(t, "PWD", os.Getenv("PWD"), pwd)
+}
@@ -1,6 +1,9 @@
 package faket
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/prashantv/faket/internal/syncutil"
@@ -54,3 +57,39 @@ func TestFakeT_PanicRace(t *testing.T) {
 		}
 	})
 }
+
+func TestFakeT_TempDirPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"TestFoo", "TestFoo"},
+		{"TestFoo/sub_test#01", "TestFoosub_test#01"},
+		{"Test*?[]:\\\"'<>|", "Test"},
+		{"Test!#$%&()+,-.=@^_{}~ ", "Test!#$%&()+,-.=@^_{}~ "},
+		{"Testé日本", "Testé日本"},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+		{strings.Repeat("a", 63) + "é", strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		want.Equal(t, "tempDirPattern", tempDirPattern(tt.name), tt.want)
+	}
+}
+
+func TestFakeT_TempDir(t *testing.T) {
+	var dirs []string
+	res := RunTest(func(t testing.TB) {
+		dirs = append(dirs, t.TempDir(), t.TempDir())
+	})
+	res.MustPass(t)
+
+	want.DeepEqual(t, "TempDirs", res.TempDirs(), dirs)
+	want.Equal(t, "first", filepath.Base(dirs[0]), "001")
+	want.Equal(t, "second", filepath.Base(dirs[1]), "002")
+	want.Equal(t, "same parent", filepath.Dir(dirs[0]), filepath.Dir(dirs[1]))
+	want.Contains(t, "parent", filepath.Base(filepath.Dir(dirs[0])), "faket-no-name")
+
+	_, err := os.Stat(filepath.Dir(dirs[0]))
+	want.Equal(t, "parent removed", os.IsNotExist(err), true)
+}
@@ -0,0 +1,84 @@
+package faket
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errInjectedFault is used for faults that don't specify an error.
+var errInjectedFault = errors.New("faket: injected fault")
+
+// Fault is a failure to inject into an operation of the fake [testing.TB],
+// to test how helpers handle the operation failing.
+type Fault struct {
+	// Op is the operation to fail.
+	Op FaultOp
+
+	// N is the call to fail, starting from 1 for the first call of Op.
+	// If N is 0, all calls fail.
+	N int
+
+	// Err is the error the operation fails with. If nil, a generic error is used.
+	Err error
+}
+
+// FaultOp is an operation that supports fault injection.
+//
+// Faults for operations in cleanups are reported after the cleanup
+// runs, so the process is still restored.
+type FaultOp int
+
+// FaultOp values.
+const (
+	// FaultTempDir fails TempDir.
+	FaultTempDir FaultOp = iota + 1
+	// FaultTempDirCleanup fails the cleanup that removes the TempDir parent directory.
+	FaultTempDirCleanup
+	// FaultSetenv fails Setenv, including the Setenv of PWD by Chdir.
+	FaultSetenv
+	// FaultSetenvRestore fails the cleanup that restores a Setenv.
+	FaultSetenvRestore
+	// FaultChdir fails Chdir.
+	FaultChdir
+	// FaultChdirRestore fails the cleanup that restores the working directory
+	// after Chdir.
+	FaultChdirRestore
+)
+
+func (op FaultOp) String() string {
+	switch op {
+	case FaultTempDir:
+		return "TempDir"
+	case FaultTempDirCleanup:
+		return "TempDirCleanup"
+	case FaultSetenv:
+		return "Setenv"
+	case FaultSetenvRestore:
+		return "SetenvRestore"
+	case FaultChdir:
+		return "Chdir"
+	case FaultChdirRestore:
+		return "ChdirRestore"
+	default:
+		return fmt.Sprintf("FaultOp(%d)", int(op))
+	}
+}
+
+// fault records a call to op, and returns the error for a matching fault.
+func (tb *fakeTB) fault(op FaultOp) error {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.faultCalls[op]++
+	n := tb.faultCalls[op]
+	for _, f := range tb.opts.Faults {
+		if f.Op != op || (f.N != 0 && f.N != n) {
+			continue
+		}
+		if f.Err == nil {
+			return errInjectedFault
+		}
+		return f.Err
+	}
+	return nil
+}
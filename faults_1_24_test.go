@@ -0,0 +1,50 @@
+//go:build go1.24
+
+package faket
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestFaultsChdir(t *testing.T) {
+	wd, err := os.Getwd()
+	want.NoErr(t, err)
+
+	tests := []struct {
+		name     string
+		op       FaultOp
+		wantFail string
+	}{
+		{
+			name:     "Chdir",
+			op:       FaultChdir,
+			wantFail: "faket: injected fault",
+		},
+		{
+			name:     "Chdir restore",
+			op:       FaultChdirRestore,
+			wantFail: "ChDir failed to ChDir to original directory: faket: injected fault",
+		},
+		{
+			name:     "Setenv of PWD",
+			op:       FaultSetenv,
+			wantFail: "cannot set environment variable: faket: injected fault",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTestOpts(Opts{Faults: []Fault{{Op: tt.op}}}, func(t testing.TB) {
+				t.Chdir(os.TempDir())
+			})
+			tr.MustFail(t, tt.wantFail)
+
+			gotWd, err := os.Getwd()
+			want.NoErr(t, err)
+			want.Equal(t, "working directory", gotWd, wd)
+		})
+	}
+}
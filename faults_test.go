@@ -0,0 +1,102 @@
+package faket
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+const faultsEnvKey = "FAKET_FAULTS_TEST"
+
+func TestFaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		faults   []Fault
+		fn       func(t testing.TB)
+		wantFail string // empty if the test should pass
+	}{
+		{
+			name:   "TempDir fails on matching call",
+			faults: []Fault{{Op: FaultTempDir, N: 2, Err: syscall.EACCES}},
+			fn: func(t testing.TB) {
+				t.TempDir()
+				t.TempDir()
+				t.Error("unreachable")
+			},
+			wantFail: "TempDir: permission denied",
+		},
+		{
+			name:   "TempDir fails on all calls",
+			faults: []Fault{{Op: FaultTempDir}},
+			fn: func(t testing.TB) {
+				t.TempDir()
+			},
+			wantFail: "TempDir: faket: injected fault",
+		},
+		{
+			name:   "TempDir not called enough times",
+			faults: []Fault{{Op: FaultTempDir, N: 2}},
+			fn: func(t testing.TB) {
+				t.TempDir()
+			},
+		},
+		{
+			name:   "TempDir cleanup",
+			faults: []Fault{{Op: FaultTempDirCleanup, Err: syscall.EBUSY}},
+			fn: func(t testing.TB) {
+				t.TempDir()
+			},
+			wantFail: "TempDir RemoveAll cleanup: device or resource busy",
+		},
+		{
+			name:   "Setenv",
+			faults: []Fault{{Op: FaultSetenv}},
+			fn: func(t testing.TB) {
+				t.Setenv(faultsEnvKey, "value")
+			},
+			wantFail: "cannot set environment variable: faket: injected fault",
+		},
+		{
+			name:   "Setenv restore",
+			faults: []Fault{{Op: FaultSetenvRestore}},
+			fn: func(t testing.TB) {
+				t.Setenv(faultsEnvKey, "value")
+			},
+			wantFail: "cannot revert environment variable: faket: injected fault",
+		},
+		{
+			name:   "other ops are unaffected",
+			faults: []Fault{{Op: FaultSetenv}},
+			fn: func(t testing.TB) {
+				t.TempDir()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTestOpts(Opts{Faults: tt.faults}, tt.fn)
+			if tt.wantFail == "" {
+				tr.MustPass(t)
+			} else {
+				tr.MustFail(t, tt.wantFail)
+			}
+
+			// Faults should not leak changes to the process.
+			for _, d := range tr.TempDirs() {
+				_, err := os.Stat(d)
+				want.Equal(t, "TempDir removed", os.IsNotExist(err), true)
+			}
+			_, ok := os.LookupEnv(faultsEnvKey)
+			want.Equal(t, "env set", ok, false)
+		})
+	}
+}
+
+func TestFaultOpString(t *testing.T) {
+	want.Equal(t, "TempDir", FaultTempDir.String(), "TempDir")
+	want.Equal(t, "ChdirRestore", FaultChdirRestore.String(), "ChdirRestore")
+	want.Equal(t, "unknown", FaultOp(0).String(), "FaultOp(0)")
+}
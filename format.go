@@ -0,0 +1,158 @@
+package faket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FormatOpts are options to customize how [Logs] are formatted.
+type FormatOpts struct {
+	// ModulePaths formats caller files relative to the root of their module
+	// (e.g., "internal/want/want.go") rather than using the file's base name.
+	// Files that aren't in a module always use the base name.
+	ModulePaths bool
+
+	// CallerFunc includes the caller's function before the message.
+	CallerFunc bool
+
+	// Kind includes the kind of the log (e.g., "[error]") before the message.
+	Kind bool
+
+	// Framing adds test status lines, as printed by `go test`.
+	Framing Framing
+
+	// Name, Outcome and Elapsed are used in framing lines.
+	// [TestResult.Format] sets Name and Outcome if they're unset.
+	Name    string
+	Outcome Outcome
+	Elapsed time.Duration
+}
+
+// Framing controls the test status lines added around logs.
+type Framing int
+
+// Framing values.
+const (
+	// FramingNone only includes the log lines, without indentation.
+	FramingNone Framing = iota
+
+	// FramingVerbose matches `go test -v`, which prints "=== RUN",
+	// the logs, followed by "--- PASS", "--- FAIL" or "--- SKIP".
+	FramingVerbose
+
+	// FramingNonVerbose matches `go test` without -v, which only prints
+	// failed tests with "--- FAIL" followed by the logs.
+	FramingNonVerbose
+)
+
+// indent matches the indentation used by the testing package.
+const indent = "    "
+
+// Format returns the log output, customized by opts.
+func (ls Logs) Format(opts FormatOpts) string {
+	var buf strings.Builder
+	switch opts.Framing {
+	case FramingVerbose:
+		fmt.Fprintf(&buf, "=== RUN   %s\n", opts.Name)
+		ls.writeIndented(&buf, opts)
+		buf.WriteString(opts.statusLine())
+	case FramingNonVerbose:
+		if opts.Outcome != OutcomeFail {
+			return ""
+		}
+		buf.WriteString(opts.statusLine())
+		ls.writeIndented(&buf, opts)
+	default:
+		for _, l := range ls {
+			buf.WriteString(opts.formatLog(l))
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
+
+// Format returns the test's log output, customized by opts.
+// Unlike [Logs.Format], the test's name and outcome are used
+// for framing if they're not set in opts.
+func (r TestResult) Format(opts FormatOpts) string {
+	if opts.Name == "" {
+		opts.Name = r.res.Name()
+	}
+	if opts.Outcome == 0 {
+		opts.Outcome = r.Outcome()
+	}
+	return r.Logs().Format(opts)
+}
+
+// writeIndented writes logs indented as the testing package does,
+// where subsequent lines of a log are indented further.
+func (ls Logs) writeIndented(buf *strings.Builder, opts FormatOpts) {
+	for _, l := range ls {
+		buf.WriteString(indent)
+		buf.WriteString(strings.ReplaceAll(opts.formatLog(l), "\n", "\n"+indent+indent))
+		buf.WriteByte('\n')
+	}
+}
+
+func (opts FormatOpts) statusLine() string {
+	return fmt.Sprintf("--- %s: %s (%.2fs)\n", opts.Outcome, opts.Name, opts.Elapsed.Seconds())
+}
+
+func (opts FormatOpts) formatLog(l Log) string {
+	file := filepath.Base(l.CallerFile)
+	if opts.ModulePaths {
+		file = modulePath(l.CallerFile)
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s:%d: ", file, l.CallerLine)
+	if opts.Kind {
+		fmt.Fprintf(&buf, "[%v] ", l.Kind)
+	}
+	if opts.CallerFunc && l.CallerFunc != "" {
+		fmt.Fprintf(&buf, "%s: ", l.CallerFunc)
+	}
+	buf.WriteString(l.Message)
+	return buf.String()
+}
+
+// moduleRoots caches the module root for a directory ("" if there's none).
+var moduleRoots sync.Map
+
+// modulePath returns file relative to the root of its module.
+func modulePath(file string) string {
+	if !filepath.IsAbs(file) {
+		return filepath.Base(file)
+	}
+
+	root := moduleRoot(filepath.Dir(file))
+	if root == "" {
+		return filepath.Base(file)
+	}
+
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return filepath.Base(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func moduleRoot(dir string) string {
+	if root, ok := moduleRoots.Load(dir); ok {
+		return root.(string)
+	}
+
+	var root string
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		root = dir
+	} else if parent := filepath.Dir(dir); parent != dir {
+		root = moduleRoot(parent)
+	}
+
+	moduleRoots.Store(dir, root)
+	return root
+}
@@ -0,0 +1,139 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestFormat(t *testing.T) {
+	failing := RunTest(func(t testing.TB) {
+		t.Log("log")
+		formatHelper(t, "multi\nline")
+	})
+	passing := RunTest(func(t testing.TB) {
+		t.Log("passed")
+	})
+	skipped := RunTest(func(t testing.TB) {
+		t.Skip("skipped")
+	})
+
+	tests := []struct {
+		name string
+		tr   TestResult
+		opts FormatOpts
+		want string
+	}{
+		{
+			name: "default",
+			tr:   failing,
+			want: "format_test.go:0: log\n" +
+				"format_test.go:0: multi\nline\n",
+		},
+		{
+			name: "module paths",
+			tr:   failing,
+			opts: FormatOpts{ModulePaths: true},
+			want: "format_test.go:0: log\n" +
+				"format_test.go:0: multi\nline\n",
+		},
+		{
+			name: "kind and caller func",
+			tr:   failing,
+			opts: FormatOpts{Kind: true, CallerFunc: true},
+			want: "format_test.go:0: [log] github.com/prashantv/faket.TestFormat.func1: log\n" +
+				"format_test.go:0: [error] github.com/prashantv/faket.TestFormat.func1: multi\nline\n",
+		},
+		{
+			name: "verbose failed",
+			tr:   failing,
+			opts: FormatOpts{Framing: FramingVerbose},
+			want: "=== RUN   faket-no-name\n" +
+				"    format_test.go:0: log\n" +
+				"    format_test.go:0: multi\n" +
+				"        line\n" +
+				"--- FAIL: faket-no-name (0.00s)\n",
+		},
+		{
+			name: "verbose passed with name",
+			tr:   passing,
+			opts: FormatOpts{Framing: FramingVerbose, Name: "TestFoo"},
+			want: "=== RUN   TestFoo\n" +
+				"    format_test.go:0: passed\n" +
+				"--- PASS: TestFoo (0.00s)\n",
+		},
+		{
+			name: "verbose skipped",
+			tr:   skipped,
+			opts: FormatOpts{Framing: FramingVerbose},
+			want: "=== RUN   faket-no-name\n" +
+				"    format_test.go:0: skipped\n" +
+				"--- SKIP: faket-no-name (0.00s)\n",
+		},
+		{
+			name: "non-verbose failed",
+			tr:   failing,
+			opts: FormatOpts{Framing: FramingNonVerbose},
+			want: "--- FAIL: faket-no-name (0.00s)\n" +
+				"    format_test.go:0: log\n" +
+				"    format_test.go:0: multi\n" +
+				"        line\n",
+		},
+		{
+			name: "non-verbose passed",
+			tr:   passing,
+			opts: FormatOpts{Framing: FramingNonVerbose},
+			want: "",
+		},
+		{
+			name: "non-verbose skipped",
+			tr:   skipped,
+			opts: FormatOpts{Framing: FramingNonVerbose},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Normalizer{LineNumbers: true}.Normalize(tt.tr.Format(tt.opts))
+			want.Equal(t, "Format", got, tt.want)
+		})
+	}
+}
+
+func formatHelper(t testing.TB, msg string) {
+	t.Helper()
+	t.Error(msg)
+}
+
+func TestFormatModulePaths(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "relative/path/foo.go", want: "foo.go"},
+		{file: "/no/module/foo.go", want: "foo.go"},
+	}
+
+	for _, tt := range tests {
+		want.Equal(t, "modulePath("+tt.file+")", modulePath(tt.file), tt.want)
+	}
+
+	tr := RunTest(func(t testing.TB) {
+		formatHelper(t, "failed")
+	})
+	logs := tr.Logs()
+	want.Equal(t, "Kind", logs[0].Kind, LogKindError)
+
+	// Attribute the log to a file in a nested directory of the module.
+	logs[0].CallerFile = logs[0].CallerFile[:len(logs[0].CallerFile)-len("format_test.go")] + "internal/want/want.go"
+	got := logs.Format(FormatOpts{ModulePaths: true})
+	want.Contains(t, "Format", got, "internal/want/want.go:")
+}
+
+func TestLogKindString(t *testing.T) {
+	want.Equal(t, "LogKindError", LogKindError.String(), "error")
+	want.Equal(t, "unknown LogKind", LogKind(0).String(), "LogKind(0)")
+	want.Equal(t, "OutcomeFail", OutcomeFail.String(), "FAIL")
+	want.Equal(t, "unknown Outcome", Outcome(0).String(), "Outcome(0)")
+}
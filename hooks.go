@@ -0,0 +1,42 @@
+package faket
+
+import "testing"
+
+// Hooks are callbacks that are called while the test runs, set using [Opts.Hooks].
+//
+// Hooks are called synchronously on the goroutine that triggered them,
+// and are passed the fake [testing.TB], which runs calls through any
+// [Opts.Interceptors]. When called on the test's goroutine, hooks can stop
+// the test early using FailNow or SkipNow.
+type Hooks struct {
+	// OnLog is called after each log is recorded.
+	OnLog func(t testing.TB, l Log)
+
+	// OnFail is called when the test is first marked as failed,
+	// including when the test panics.
+	OnFail func(t testing.TB)
+
+	// OnSkip is called when the test is skipped, before it stops running.
+	OnSkip func(t testing.TB)
+
+	// OnCleanup is called before each cleanup function runs.
+	OnCleanup func(t testing.TB)
+}
+
+// ForwardTo returns hooks that log each log to `t` as it's recorded,
+// at the log's caller location (see [Spy] for limitations before Go 1.25).
+// This is useful to watch the progress of long-running tests.
+func ForwardTo(t testing.TB) Hooks {
+	return Hooks{
+		OnLog: func(_ testing.TB, l Log) {
+			logTo(t, l)
+		},
+	}
+}
+
+// runHooks calls fn for each of the fake's hooks.
+func (tb *fakeTB) runHooks(fn func(h Hooks)) {
+	for _, h := range tb.opts.Hooks {
+		fn(h)
+	}
+}
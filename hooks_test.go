@@ -0,0 +1,135 @@
+package faket
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+// recordHooks returns hooks that record each call to events with the given prefix.
+func recordHooks(prefix string, events *[]string) Hooks {
+	record := func(format string, args ...any) {
+		*events = append(*events, prefix+fmt.Sprintf(format, args...))
+	}
+	return Hooks{
+		OnLog:     func(_ testing.TB, l Log) { record("log %v: %v", l.Kind, l.Message) },
+		OnFail:    func(testing.TB) { record("fail") },
+		OnSkip:    func(testing.TB) { record("skip") },
+		OnCleanup: func(testing.TB) { record("cleanup") },
+	}
+}
+
+func TestHooks(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t testing.TB)
+		want []string
+	}{
+		{
+			name: "events in order",
+			fn: func(t testing.TB) {
+				t.Cleanup(func() {
+					t.Log("in cleanup")
+				})
+				t.Log("log")
+				t.Error("error 1")
+				t.Error("error 2")
+				t.Skip("skip")
+			},
+			want: []string{
+				"log log: log",
+				"log error: error 1",
+				"fail",
+				"log error: error 2",
+				"log skip: skip",
+				"skip",
+				"cleanup",
+				"log log: in cleanup",
+			},
+		},
+		{
+			name: "panic",
+			fn: func(t testing.TB) {
+				panic("boom")
+			},
+			want: []string{
+				"log panic: panic: boom",
+				"fail",
+			},
+		},
+		{
+			name: "fail after panic",
+			fn: func(t testing.TB) {
+				t.Cleanup(func() {
+					t.Fail()
+				})
+				panic("boom")
+			},
+			want: []string{
+				"cleanup",
+				"fail",
+				"log panic: panic: boom",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			RunTestOpts(Opts{Hooks: []Hooks{recordHooks("", &events)}}, tt.fn)
+			want.DeepEqual(t, "events", events, tt.want)
+		})
+	}
+}
+
+func TestHooksOrder(t *testing.T) {
+	var events []string
+	RunTestOpts(Opts{
+		Hooks: []Hooks{
+			recordHooks("1 ", &events),
+			{}, // hooks can be partially set
+			recordHooks("2 ", &events),
+		},
+	}, func(t testing.TB) {
+		t.Log("log")
+	})
+
+	want.DeepEqual(t, "events", events, []string{"1 log log: log", "2 log log: log"})
+}
+
+func TestHooksStopTest(t *testing.T) {
+	var afterStop bool
+	tr := RunTestOpts(Opts{
+		Hooks: []Hooks{{
+			OnLog: func(t testing.TB, l Log) {
+				if l.Message == "done" {
+					t.SkipNow()
+				}
+			},
+		}},
+	}, func(t testing.TB) {
+		t.Log("done")
+		afterStop = true
+	})
+
+	want.Equal(t, "Outcome", tr.Outcome(), OutcomeSkip)
+	want.Equal(t, "ran after stop", afterStop, false)
+}
+
+func TestForwardTo(t *testing.T) {
+	var inner TestResult
+	outer := RunTest(func(outerT testing.TB) {
+		outerRes := TestResult{outerT.(*fakeTB)}
+		inner = RunTestOpts(Opts{Hooks: []Hooks{ForwardTo(outerT)}}, func(t testing.TB) {
+			t.Log("log")
+			want.DeepEqual(t, "forwarded before test completes", outerRes.Logs().Messages(), []string{"log"})
+			errorHelper(t, "failed")
+		})
+	})
+
+	// Only logs are forwarded, so the outer test passes.
+	outer.MustPass(t)
+	want.Equal(t, "inner Failed", inner.Failed(), true)
+	want.DeepEqual(t, "forwarded Logs", outer.Logs(), inner.Logs())
+}
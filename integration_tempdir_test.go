@@ -0,0 +1,33 @@
+package faket_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prashantv/faket/internal/cmptest"
+)
+
+func TestCmp_TempDirLayout(t *testing.T) {
+	cmptest.Compare(t, func(t testing.TB) {
+		var parent string
+		t.Cleanup(func() {
+			_, err := os.Stat(parent)
+			t.Logf("parent removed after cleanup: %v", os.IsNotExist(err))
+		})
+
+		d1 := t.TempDir()
+		d2 := t.TempDir()
+		parent = filepath.Dir(d1)
+		t.Logf("children: %v %v", filepath.Base(d1), filepath.Base(d2))
+		t.Logf("same parent: %v", filepath.Dir(d2) == parent)
+
+		// Removing the parent creates a new parent, but the sequence continues.
+		if err := os.RemoveAll(parent); err != nil {
+			t.Fatalf("RemoveAll failed: %v", err)
+		}
+		d3 := t.TempDir()
+		t.Logf("after removing parent: %v, new parent: %v", filepath.Base(d3), filepath.Dir(d3) != parent)
+		parent = filepath.Dir(d3)
+	})
+}
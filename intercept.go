@@ -0,0 +1,313 @@
+package faket
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/prashantv/faket/internal/tblog"
+)
+
+// Call is a call to a method of the fake [testing.TB], passed to an [Interceptor].
+type Call struct {
+	// Method is the name of the method, such as "Errorf".
+	Method string
+
+	// Args are the arguments to the method. Variadic arguments are flattened,
+	// so the Args for Logf are the format followed by the format arguments.
+	//
+	// Interceptors may replace Args, but each argument must keep the type
+	// of the method's parameter (e.g., the format for Logf must be a string).
+	Args []any
+
+	// CallerFile, CallerLine and CallerFunc are the location the method
+	// was called from. Unlike [Log], helpers are not skipped.
+	CallerFile string
+	CallerLine int
+	CallerFunc string
+
+	callers  []uintptr // callers[0] is the intercepted method.
+	resolved *Log      // set for logs with a caller resolved elsewhere.
+}
+
+// Interceptor intercepts calls to methods of the fake [testing.TB],
+// set using [Opts.Interceptors].
+type Interceptor interface {
+	// Intercept is called for each method call, and runs the call by calling next
+	// with the same or a modified Call, returning next's results.
+	//
+	// If next isn't called, the call is vetoed, and the method returns
+	// the results returned by Intercept, using zero values for missing results.
+	//
+	// Calls that stop the test, such as Fatal or SkipNow (or TempDir when it fails),
+	// don't return from next, so code after next doesn't run. Use defer for
+	// code that must run after every call.
+	Intercept(c Call, next func(Call) []any) []any
+}
+
+// InterceptorFunc is an adapter to use a function as an [Interceptor].
+type InterceptorFunc func(c Call, next func(Call) []any) []any
+
+// Intercept calls f(c, next).
+func (f InterceptorFunc) Intercept(c Call, next func(Call) []any) []any {
+	return f(c, next)
+}
+
+// interceptedTB is passed to the test function when interceptors are set
+// or calls are recorded, and runs each method call through the interceptors before the fake.
+type interceptedTB struct {
+	*fakeTB
+
+	// indirect is set for calls that aren't made by the test,
+	// which are intercepted but not recorded.
+	indirect bool
+}
+
+// testTB returns the testing.TB passed to the test function.
+func (tb *fakeTB) testTB() testing.TB {
+	if len(tb.opts.Interceptors) == 0 && !tb.opts.RecordCalls {
+		return tb
+	}
+	return &interceptedTB{fakeTB: tb}
+}
+
+// indirectTB returns the testing.TB passed to Hooks and Behaviors.
+func (tb *fakeTB) indirectTB() testing.TB {
+	if len(tb.opts.Interceptors) == 0 {
+		return tb
+	}
+	return &interceptedTB{fakeTB: tb, indirect: true}
+}
+
+// intercept records a call to the method by the test if needed, runs it
+// through the interceptors, and returns the results. Callers are captured
+// here so logs and helpers are attributed to the test code rather than
+// the interceptors.
+func (it *interceptedTB) intercept(method string, args ...any) []any {
+	c := newCall(method, getCallers(skipSelf))
+	c.Args = args
+
+	if it.opts.RecordCalls && !it.indirect {
+		func() {
+			it.mu.Lock()
+			defer it.mu.Unlock()
+
+			it.calls = append(it.calls, c)
+		}()
+	}
+	return it.run(c)
+}
+
+// newCall returns a call to method, where callers[0] is the intercepted method.
+func newCall(method string, callers []uintptr) Call {
+	c := Call{
+		Method:  method,
+		callers: callers,
+	}
+	if len(callers) > 1 {
+		f, _ := runtime.CallersFrames(callers[1:]).Next()
+		c.CallerFile = f.File
+		c.CallerLine = f.Line
+		c.CallerFunc = f.Function
+	}
+	return c
+}
+
+// run runs the call through the interceptors. Calls that aren't made
+// directly by the test, such as by Hooks and Behaviors, aren't recorded.
+func (it *interceptedTB) run(c Call) []any {
+	next := it.invoke
+	interceptors := it.opts.Interceptors
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(c Call) []any {
+			return interceptor.Intercept(c, inner)
+		}
+	}
+	return next(c)
+}
+
+// logResolved runs a log with a caller location resolved elsewhere, such as
+// by ReplayTo or ForwardTo, through the interceptors as a call to Log.
+func (it *interceptedTB) logResolved(l Log) {
+	it.run(Call{
+		Method:     "Log",
+		Args:       []any{l.Message},
+		CallerFile: l.CallerFile,
+		CallerLine: l.CallerLine,
+		CallerFunc: l.CallerFunc,
+		resolved:   &l,
+	})
+}
+
+// RecordLog runs a log from other packages in this module through the
+// interceptors as a call to Log, see tblog.LogTo.
+func (it *interceptedTB) RecordLog(e tblog.Entry) {
+	it.logResolved(entryToLog(e))
+}
+
+// markHelper runs a helper marked by a Spy through the interceptors
+// as a call to Helper.
+func (it *interceptedTB) markHelper(pc uintptr) {
+	// The intercepted method is unknown, so callers[0] is unset.
+	it.run(newCall("Helper", []uintptr{0, pc}))
+}
+
+// invoke runs the call using the fake, after all interceptors.
+func (it *interceptedTB) invoke(c Call) []any {
+	tb := it.fakeTB
+	switch c.Method {
+	case "Error":
+		tb.log(c.callers, LogKindError, sprintln(c.Args...))
+		tb.Fail()
+	case "Errorf":
+		tb.log(c.callers, LogKindError, sprintfArgs(c.Args))
+		tb.Fail()
+	case "Fatal":
+		tb.log(c.callers, LogKindFatal, sprintln(c.Args...))
+		tb.FailNow()
+	case "Fatalf":
+		tb.log(c.callers, LogKindFatal, sprintfArgs(c.Args))
+		tb.FailNow()
+	case "Log":
+		if c.resolved != nil {
+			l := *c.resolved
+			l.Message = sprintln(c.Args...)
+			tb.logResolved(l)
+			break
+		}
+		tb.log(c.callers, LogKindLog, sprintln(c.Args...))
+	case "Logf":
+		tb.log(c.callers, LogKindLog, sprintfArgs(c.Args))
+	case "Skip":
+		tb.log(c.callers, LogKindSkip, sprintln(c.Args...))
+		tb.SkipNow()
+	case "Skipf":
+		tb.log(c.callers, LogKindSkip, sprintfArgs(c.Args))
+		tb.SkipNow()
+	case "Fail":
+		tb.Fail()
+	case "FailNow":
+		tb.FailNow()
+	case "SkipNow":
+		tb.SkipNow()
+	case "Failed":
+		return []any{tb.Failed()}
+	case "Skipped":
+		return []any{tb.Skipped()}
+	case "Helper":
+		if len(c.callers) > 1 {
+			tb.markHelper(c.callers[1])
+		}
+	case "Cleanup":
+		tb.addCleanup(c.callers[1:], c.Args[0].(func()))
+	case "Name":
+		return []any{tb.Name()}
+	case "TempDir":
+		return []any{tb.TempDir()}
+	case "Setenv":
+		tb.Setenv(c.Args[0].(string), c.Args[1].(string))
+	case "Chdir":
+		tb.Chdir(c.Args[0].(string))
+	case "Context":
+		return []any{tb.Context()}
+	default:
+		panic(fmt.Sprintf("faket: unknown intercepted method %q", c.Method))
+	}
+	return nil
+}
+
+// sprintfArgs formats args where the first argument is the format.
+func sprintfArgs(args []any) string {
+	return fmt.Sprintf(args[0].(string), args[1:]...)
+}
+
+// result returns the first result if it has type T, or the zero value.
+func result[T any](results []any) T {
+	var v T
+	if len(results) > 0 {
+		v, _ = results[0].(T)
+	}
+	return v
+}
+
+func (it *interceptedTB) Error(args ...any) {
+	it.intercept("Error", args...)
+}
+
+func (it *interceptedTB) Errorf(format string, args ...any) {
+	it.intercept("Errorf", append([]any{format}, args...)...)
+}
+
+func (it *interceptedTB) Fatal(args ...any) {
+	it.intercept("Fatal", args...)
+}
+
+func (it *interceptedTB) Fatalf(format string, args ...any) {
+	it.intercept("Fatalf", append([]any{format}, args...)...)
+}
+
+func (it *interceptedTB) Log(args ...any) {
+	it.intercept("Log", args...)
+}
+
+func (it *interceptedTB) Logf(format string, args ...any) {
+	it.intercept("Logf", append([]any{format}, args...)...)
+}
+
+func (it *interceptedTB) Skip(args ...any) {
+	it.intercept("Skip", args...)
+}
+
+func (it *interceptedTB) Skipf(format string, args ...any) {
+	it.intercept("Skipf", append([]any{format}, args...)...)
+}
+
+func (it *interceptedTB) Fail() {
+	it.intercept("Fail")
+}
+
+func (it *interceptedTB) FailNow() {
+	it.intercept("FailNow")
+}
+
+func (it *interceptedTB) SkipNow() {
+	it.intercept("SkipNow")
+}
+
+func (it *interceptedTB) Failed() bool {
+	return result[bool](it.intercept("Failed"))
+}
+
+func (it *interceptedTB) Skipped() bool {
+	return result[bool](it.intercept("Skipped"))
+}
+
+func (it *interceptedTB) Helper() {
+	it.intercept("Helper")
+}
+
+func (it *interceptedTB) Cleanup(f func()) {
+	it.intercept("Cleanup", f)
+}
+
+func (it *interceptedTB) Name() string {
+	return result[string](it.intercept("Name"))
+}
+
+func (it *interceptedTB) TempDir() string {
+	return result[string](it.intercept("TempDir"))
+}
+
+func (it *interceptedTB) Setenv(key, value string) {
+	it.intercept("Setenv", key, value)
+}
+
+func (it *interceptedTB) Chdir(dir string) {
+	it.intercept("Chdir", dir)
+}
+
+func (it *interceptedTB) Context() context.Context {
+	return result[context.Context](it.intercept("Context"))
+}
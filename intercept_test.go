@@ -0,0 +1,242 @@
+package faket
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/faket/internal/tblog"
+	"github.com/prashantv/faket/internal/want"
+)
+
+// recordCalls returns an interceptor that records each call to calls with the given prefix.
+func recordCalls(prefix string, calls *[]string) Interceptor {
+	return InterceptorFunc(func(c Call, next func(Call) []any) []any {
+		args := fmt.Sprint(c.Args)
+		if c.Method == "Cleanup" {
+			args = "[func]"
+		}
+		*calls = append(*calls, prefix+c.Method+args)
+		return next(c)
+	})
+}
+
+// redactCalls returns an interceptor that redacts secret from string arguments.
+func redactCalls(secret string) Interceptor {
+	return InterceptorFunc(func(c Call, next func(Call) []any) []any {
+		args := make([]any, len(c.Args))
+		for i, arg := range c.Args {
+			if s, ok := arg.(string); ok {
+				arg = strings.ReplaceAll(s, secret, "[REDACTED]")
+			}
+			args[i] = arg
+		}
+		c.Args = args
+		return next(c)
+	})
+}
+
+func TestInterceptObserve(t *testing.T) {
+	var calls []string
+	var callers []string
+	tr := RunTestOpts(Opts{
+		Interceptors: []Interceptor{
+			recordCalls("", &calls),
+			InterceptorFunc(func(c Call, next func(Call) []any) []any {
+				callers = append(callers, c.CallerFunc)
+				return next(c)
+			}),
+		},
+	}, func(t testing.TB) {
+		t.Log("log", 1)
+		t.Cleanup(func() {
+			t.Logf("cleanup %v", 2)
+		})
+		errorHelper(t, "failed")
+		if t.Failed() {
+			t.Skip("skip")
+		}
+	})
+
+	want.DeepEqual(t, "calls", calls, []string{
+		"Log[log 1]",
+		"Cleanup[func]",
+		"Helper[]",
+		"Error[failed]",
+		"Failed[]",
+		"Skip[skip]",
+		"Logf[cleanup %v 2]",
+	})
+	want.DeepEqual(t, "callers", callers, []string{
+		"github.com/prashantv/faket.TestInterceptObserve.func2",
+		"github.com/prashantv/faket.TestInterceptObserve.func2",
+		"github.com/prashantv/faket.errorHelper",
+		"github.com/prashantv/faket.errorHelper",
+		"github.com/prashantv/faket.TestInterceptObserve.func2",
+		"github.com/prashantv/faket.TestInterceptObserve.func2",
+		"github.com/prashantv/faket.TestInterceptObserve.func2.1",
+	})
+
+	// Logs and helpers are attributed to the test, not the interceptors.
+	want.Equal(t, "Outcome", tr.Outcome(), OutcomeFail)
+	want.DeepEqual(t, "Helpers", tr.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+	for _, l := range tr.Logs() {
+		want.Equal(t, "CallerFunc", strings.HasPrefix(l.CallerFunc, "github.com/prashantv/faket.TestInterceptObserve.func2"), true)
+	}
+	tr.MustReportFromCaller(t)
+}
+
+func TestInterceptTransform(t *testing.T) {
+	redact := redactCalls("hunter2")
+	rename := InterceptorFunc(func(c Call, next func(Call) []any) []any {
+		results := next(c)
+		if c.Method == "Name" {
+			return []any{"renamed-" + results[0].(string)}
+		}
+		return results
+	})
+
+	var name string
+	tr := RunTestOpts(Opts{Interceptors: []Interceptor{redact, rename}}, func(t testing.TB) {
+		t.Log("password: hunter2")
+		t.Errorf("password %v", "hunter2")
+		name = t.Name()
+	})
+
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{
+		"password: [REDACTED]",
+		"password [REDACTED]",
+	})
+	want.Equal(t, "Name", name, "renamed-faket-no-name")
+}
+
+func TestInterceptVeto(t *testing.T) {
+	veto := func(methods ...string) Interceptor {
+		return InterceptorFunc(func(c Call, next func(Call) []any) []any {
+			for _, m := range methods {
+				if c.Method == m {
+					return nil
+				}
+			}
+			return next(c)
+		})
+	}
+
+	var completed bool
+	var tempDir string
+	tr := RunTestOpts(Opts{
+		Interceptors: []Interceptor{veto("Fatal", "Skip", "TempDir")},
+	}, func(t testing.TB) {
+		t.Fatal("fatal")
+		t.Skip("skip")
+		tempDir = t.TempDir()
+		t.Log("log")
+		completed = true
+	})
+
+	tr.MustPass(t)
+	want.Equal(t, "completed", completed, true)
+	want.Equal(t, "TempDir", tempDir, "")
+	want.Equal(t, "TempDirs", len(tr.TempDirs()), 0)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log"})
+}
+
+func TestInterceptOrder(t *testing.T) {
+	var calls []string
+	RunTestOpts(Opts{
+		Interceptors: []Interceptor{
+			recordCalls("1 ", &calls),
+			InterceptorFunc(func(c Call, next func(Call) []any) []any {
+				c.Args = []any{"modified"}
+				return next(c)
+			}),
+			recordCalls("2 ", &calls),
+		},
+	}, func(t testing.TB) {
+		t.Log("log")
+	})
+
+	want.DeepEqual(t, "calls", calls, []string{"1 Log[log]", "2 Log[modified]"})
+}
+
+func TestInterceptNone(t *testing.T) {
+	RunTest(func(t testing.TB) {
+		if _, ok := t.(*fakeTB); !ok {
+			t.Errorf("expected *fakeTB without interceptors, got %T", t)
+		}
+	}).MustPass(t)
+}
+
+func TestInterceptIndirectCalls(t *testing.T) {
+	var calls []string
+	tr := RunTestOpts(Opts{
+		Interceptors: []Interceptor{redactCalls("hunter2"), recordCalls("", &calls)},
+		Hooks: []Hooks{{
+			OnFail: func(t testing.TB) {
+				t.Log("hook: hunter2")
+			},
+		}},
+		Behaviors: Behaviors{
+			TempDir: func(t testing.TB) string {
+				t.Log("temp dir: hunter2")
+				return "/tmp/hunter2"
+			},
+		},
+	}, func(t testing.TB) {
+		t.TempDir()
+		tblog.LogTo(t, tblog.Entry{Message: "recorded: hunter2", File: "log.go", Line: 10, Func: "pkg.Log"})
+		RunTest(func(t testing.TB) {
+			t.Error("replayed: hunter2")
+		}).ReplayTo(t)
+
+		spy, _ := Spy(t)
+		func(t testing.TB) {
+			t.Helper()
+			t.Log("spied: hunter2")
+		}(spy)
+	})
+
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{
+		"temp dir: [REDACTED]",
+		"recorded: [REDACTED]",
+		"replayed: [REDACTED]",
+		"hook: [REDACTED]",
+		"spied: [REDACTED]",
+	})
+	want.DeepEqual(t, "calls", calls, []string{
+		"TempDir[]",
+		"Log[temp dir: [REDACTED]]",
+		"Log[recorded: [REDACTED]]",
+		"Helper[]",
+		"Log[replayed: [REDACTED]]",
+		"Fail[]",
+		"Log[hook: [REDACTED]]",
+		"Helper[]",
+		"Log[spied: [REDACTED]]",
+	})
+	want.Equal(t, "resolved CallerFile", tr.Logs()[1].CallerFile, "log.go")
+}
+
+func TestInterceptStopsTest(t *testing.T) {
+	var events []string
+	tr := RunTestOpts(Opts{
+		Interceptors: []Interceptor{InterceptorFunc(func(c Call, next func(Call) []any) []any {
+			defer func() {
+				events = append(events, "deferred "+c.Method)
+			}()
+			results := next(c)
+			events = append(events, "after "+c.Method)
+			return results
+		})},
+	}, func(t testing.TB) {
+		t.Log("log")
+		t.Fatal("fatal")
+	})
+
+	tr.MustFail(t, "fatal")
+	want.DeepEqual(t, "events", events, []string{
+		"after Log",
+		"deferred Log",
+		"deferred Fatal",
+	})
+}
@@ -0,0 +1,125 @@
+// Package diff has helpers for showing differences in assertion failures.
+package diff
+
+import (
+	"strings"
+)
+
+// Op is the operation for a single step in an alignment.
+type Op int
+
+// Op values.
+const (
+	// Equal is an element that is in both sequences.
+	Equal Op = iota
+	// Delete is an element only in the first sequence.
+	Delete
+	// Insert is an element only in the second sequence.
+	Insert
+)
+
+// Edit is a single step in an alignment of two sequences.
+// A is the index in the first sequence (for Equal and Delete),
+// and B is the index in the second sequence (for Equal and Insert).
+type Edit struct {
+	Op   Op
+	A, B int
+}
+
+// Align aligns sequences of length n and m using their longest common
+// subsequence, where eq reports whether a[i] matches b[j].
+func Align(n, m int, eq func(i, j int) bool) []Edit {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]Edit, 0, max(n, m))
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && eq(i, j):
+			edits = append(edits, Edit{Op: Equal, A: i, B: j})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] > lcs[i+1][j]):
+			edits = append(edits, Edit{Op: Insert, A: i, B: j})
+			j++
+		default:
+			edits = append(edits, Edit{Op: Delete, A: i, B: j})
+			i++
+		}
+	}
+	return edits
+}
+
+// Lines returns a line-oriented diff of want and got. Each line is
+// prefixed with "-" if it's only in want, "+" if it's only in got,
+// or " " if it's in both.
+func Lines(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var buf strings.Builder
+	for _, e := range Align(len(wantLines), len(gotLines), func(i, j int) bool {
+		return wantLines[i] == gotLines[j]
+	}) {
+		switch e.Op {
+		case Equal:
+			buf.WriteString(" " + wantLines[e.A] + "\n")
+		case Delete:
+			buf.WriteString("-" + wantLines[e.A] + "\n")
+		case Insert:
+			buf.WriteString("+" + gotLines[e.B] + "\n")
+		}
+	}
+	return buf.String()
+}
+
+// Similarity returns a score between 0 and 1 of how similar a and b are,
+// based on the longest common subsequence of runes.
+func Similarity(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	if len(ar)+len(br) == 0 {
+		return 1
+	}
+
+	// Only the previous row of the LCS table is needed.
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for i := range ar {
+		for j := range br {
+			if ar[i] == br[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	return 2 * float64(prev[len(br)]) / float64(len(ar)+len(br))
+}
+
+// Closest returns the index of the candidate most similar to s,
+// or -1 if there are no candidates.
+func Closest(s string, candidates []string) int {
+	closest := -1
+	var best float64
+	for i, c := range candidates {
+		if sim := Similarity(s, c); closest < 0 || sim > best {
+			closest, best = i, sim
+		}
+	}
+	return closest
+}
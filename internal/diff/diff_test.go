@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{
+			name: "equal",
+			want: "a\nb",
+			got:  "a\nb",
+			diff: " a\n b\n",
+		},
+		{
+			name: "changed line",
+			want: "got:  0\nwant: 5",
+			got:  "got:  0\nwant: 4",
+			diff: " got:  0\n-want: 5\n+want: 4\n",
+		},
+		{
+			name: "added and removed lines",
+			want: "a\nb\nc",
+			got:  "b\nc\nd",
+			diff: "-a\n b\n c\n+d\n",
+		},
+		{
+			name: "empty want",
+			want: "",
+			got:  "a",
+			diff: "-\n+a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want.Equal(t, "Lines", Lines(tt.want, tt.got), tt.diff)
+		})
+	}
+}
+
+func TestAlign(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	b := []int{2, 4, 5}
+	got := Align(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+	want.DeepEqual(t, "Align", got, []Edit{
+		{Op: Delete, A: 0, B: 0},
+		{Op: Equal, A: 1, B: 0},
+		{Op: Delete, A: 2, B: 1},
+		{Op: Equal, A: 3, B: 1},
+		{Op: Insert, A: 4, B: 2},
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	want.Equal(t, "both empty", Similarity("", ""), 1)
+	want.Equal(t, "one empty", Similarity("abc", ""), 0)
+	want.Equal(t, "equal", Similarity("abc", "abc"), 1)
+	want.Equal(t, "half", Similarity("ab", "ac"), 0.5)
+}
+
+func TestClosest(t *testing.T) {
+	want.Equal(t, "no candidates", Closest("a", nil), -1)
+	want.Equal(t, "closest", Closest("failed to find foo", []string{
+		"unrelated",
+		"failed to find bar",
+		"failed",
+	}), 1)
+}
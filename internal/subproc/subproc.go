@@ -0,0 +1,67 @@
+// Package subproc has helpers for re-running the current test
+// in a subprocess of the test binary.
+package subproc
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Calls counts the calls within each run of a test, so a call can be
+// identified when the test is re-run in a subprocess. Counts are reset
+// when the test completes, so each run of the test (e.g., using -count)
+// uses the same identifiers.
+type Calls struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// Next returns an identifier for the next call within the test `t`,
+// and the 1-based number of the call.
+func (c *Calls) Next(t testing.TB) (id string, n int) {
+	testName := t.Name()
+	n = c.next(testName)
+	if n == 1 {
+		t.Cleanup(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.counts, testName)
+		})
+	}
+	return fmt.Sprintf("%s#%d", testName, n), n
+}
+
+func (c *Calls) next(testName string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[testName]++
+	return c.counts[testName]
+}
+
+// Args returns arguments for the test binary to only run the given test,
+// using the same timeout as the current process.
+func Args(testName string) []string {
+	args := []string{"-test.run=" + RunPattern(testName)}
+	if f := flag.Lookup("test.timeout"); f != nil {
+		args = append(args, "-test.timeout="+f.Value.String())
+	}
+	return args
+}
+
+// RunPattern returns a -test.run pattern that only matches the given test.
+func RunPattern(testName string) string {
+	parts := strings.Split(testName, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,51 @@
+package subproc
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+// runTB is a testing.TB that runs cleanups when done is called.
+type runTB struct {
+	testing.TB
+
+	name     string
+	cleanups []func()
+}
+
+func (t *runTB) Name() string      { return t.name }
+func (t *runTB) Cleanup(fn func()) { t.cleanups = append(t.cleanups, fn) }
+func (t *runTB) done() {
+	for _, fn := range t.cleanups {
+		fn()
+	}
+}
+
+func TestCalls(t *testing.T) {
+	var c Calls
+
+	// Each run of a test uses the same identifiers.
+	for range 2 {
+		a := &runTB{name: "TestA"}
+		id, n := c.Next(a)
+		want.Equal(t, "id", id, "TestA#1")
+		want.Equal(t, "n", n, 1)
+
+		id, n = c.Next(a)
+		want.Equal(t, "id", id, "TestA#2")
+		want.Equal(t, "n", n, 2)
+
+		b := &runTB{name: "TestB"}
+		id, n = c.Next(b)
+		want.Equal(t, "id", id, "TestB#1")
+		want.Equal(t, "n", n, 1)
+
+		a.done()
+		b.done()
+	}
+}
+
+func TestRunPattern(t *testing.T) {
+	want.Equal(t, "RunPattern", RunPattern("TestFoo/sub.test/faket-cmpt"), `^TestFoo$/^sub\.test$/^faket-cmpt$`)
+}
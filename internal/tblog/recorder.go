@@ -0,0 +1,29 @@
+package tblog
+
+import "testing"
+
+// Entry is a log message with the location it's attributed to.
+type Entry struct {
+	Message string
+	File    string
+	Line    int
+	Func    string
+}
+
+// Recorder is implemented by fakes that record logs at a given location.
+// Since Entry is internal, only packages in this module can use it.
+type Recorder interface {
+	RecordLog(e Entry)
+}
+
+// LogTo logs e to t, attributed to the location of e. Fakes that implement
+// Recorder record the entry as-is, otherwise the entry is logged using Log.
+func LogTo(t testing.TB, e Entry) {
+	if r, ok := t.(Recorder); ok {
+		r.RecordLog(e)
+		return
+	}
+
+	t.Helper()
+	Log(t, e.File, e.Line, e.Message)
+}
@@ -0,0 +1,42 @@
+// Package tblog logs to a testing.TB with a given caller location.
+package tblog
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+)
+
+// Format formats msg the same as the testing package formats logs,
+// with the caller location, and with continuation lines indented.
+func Format(file string, line int, msg string) string {
+	if file == "" {
+		file, line = "???", 1
+	} else if !fullPath() {
+		if i := strings.LastIndexAny(file, `/\`); i >= 0 {
+			file = file[i+1:]
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(file)
+	sb.WriteString(":")
+	sb.WriteString(strconv.Itoa(line))
+	sb.WriteString(": ")
+
+	lines := strings.Split(strings.TrimSuffix(msg, "\n"), "\n")
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteString("\n    ")
+		}
+		sb.WriteString(l)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// fullPath reports whether the test binary is run with -test.fullpath.
+func fullPath() bool {
+	f := flag.Lookup("test.fullpath")
+	return f != nil && f.Value.String() == "true"
+}
@@ -0,0 +1,16 @@
+//go:build go1.25
+
+package tblog
+
+import (
+	"io"
+	"testing"
+)
+
+// usesOutput is true if Log attributes logs to the given location.
+const usesOutput = true
+
+// Log logs msg to t, attributed to file:line.
+func Log(t testing.TB, file string, line int, msg string) {
+	io.WriteString(t.Output(), Format(file, line, msg)) //nolint:errcheck // t.Output doesn't return errors.
+}
@@ -0,0 +1,18 @@
+//go:build !go1.25
+
+package tblog
+
+import "testing"
+
+// usesOutput is true if Log attributes logs to the given location.
+const usesOutput = false
+
+// Log logs msg to t, attributed to file:line.
+//
+// Before Go 1.25, there's no way to log without the testing package adding
+// the location, so the location is ignored, and the log is attributed to the
+// first caller of Log that hasn't called t.Helper.
+func Log(t testing.TB, file string, line int, msg string) {
+	t.Helper()
+	t.Log(msg)
+}
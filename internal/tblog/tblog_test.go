@@ -0,0 +1,82 @@
+package tblog
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		line int
+		msg  string
+		want string
+	}{
+		{
+			name: "single line",
+			file: "/path/to/foo_test.go",
+			line: 12,
+			msg:  "msg",
+			want: "foo_test.go:12: msg\n",
+		},
+		{
+			name: "multiple lines",
+			file: "foo_test.go",
+			line: 1,
+			msg:  "line 1\nline 2\n",
+			want: "foo_test.go:1: line 1\n    line 2\n",
+		},
+		{
+			name: "unknown location",
+			msg:  "msg",
+			want: "???:1: msg\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want.Equal(t, "Format", Format(tt.file, tt.line, tt.msg), tt.want)
+		})
+	}
+}
+
+const logSubprocessEnv = "TBLOG_TEST_SUBPROCESS"
+
+func TestLog(t *testing.T) {
+	if os.Getenv(logSubprocessEnv) != "" {
+		Log(t, "/path/to/foo_test.go", 12, "line 1\nline 2")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLog$", "-test.v")
+	cmd.Env = append(os.Environ(), logSubprocessEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	want.NoErr(t, err)
+
+	if usesOutput {
+		want.Contains(t, "output", string(out), "    foo_test.go:12: line 1\n        line 2\n")
+	} else {
+		want.Contains(t, "output", string(out), "    tblog_test.go:51: line 1\n        line 2\n")
+	}
+}
+
+type recorderTB struct {
+	testing.TB
+
+	entries []Entry
+}
+
+func (r *recorderTB) RecordLog(e Entry) {
+	r.entries = append(r.entries, e)
+}
+
+func TestLogToRecorder(t *testing.T) {
+	r := &recorderTB{TB: t}
+	e := Entry{Message: "msg", File: "foo_test.go", Line: 3, Func: "pkg.TestFoo"}
+	LogTo(r, e)
+	want.DeepEqual(t, "entries", r.entries, []Entry{e})
+}
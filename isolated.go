@@ -0,0 +1,313 @@
+package faket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/prashantv/faket/internal/subproc"
+)
+
+const (
+	// isolatedRunEnv is set in the subprocess to the call to RunIsolated to run.
+	isolatedRunEnv = "FAKET_ISOLATED_RUN"
+	// isolatedOutEnv is set in the subprocess to the file to write results to.
+	isolatedOutEnv = "FAKET_ISOLATED_OUT"
+	// isolatedPrevEnv is set in the subprocess to a file with results of
+	// earlier calls to RunIsolated in the same test.
+	isolatedPrevEnv = "FAKET_ISOLATED_PREV"
+)
+
+// RunIsolated runs the given test using a fake [testing.TB] in a subprocess
+// of the current test binary, and returns the result of running the test.
+//
+// Unlike [RunTest], the test can terminate the process, such as by calling
+// os.Exit or log.Fatal, or crashing with a fatal runtime error. Logs are
+// forwarded from the subprocess as they're written, so logs before the
+// process exited are available in the result, along with [TestResult.Exited],
+// [TestResult.ExitCode] and [TestResult.Stderr].
+//
+// The subprocess re-runs the current test from the start, until RunIsolated
+// is called, so any code before RunIsolated must be safe to run in a subprocess.
+// Results of earlier calls to RunIsolated in the same test are passed to the
+// subprocess, so they're consistent between processes.
+func RunIsolated(t *testing.T, testFn func(t testing.TB)) TestResult {
+	t.Helper()
+
+	call, n := isolatedCalls.Next(t)
+	if run, ok := os.LookupEnv(isolatedRunEnv); ok {
+		return runIsolatedChild(t, run == call, n, testFn)
+	}
+
+	res, err := runIsolatedParent(t.Name(), call, isolatedResults(t))
+	if err != nil {
+		t.Fatalf("failed to run isolated test: %v", err)
+	}
+
+	addIsolatedResult(t, res)
+	tr := res.toTestResult()
+	tr.res.testFn = reflect.ValueOf(testFn).Pointer()
+	return tr
+}
+
+// isolatedResult is the result of running a test in a subprocess,
+// which is passed between processes.
+type isolatedResult struct {
+	Logs      Logs     `json:"logs,omitempty"`
+	Completed bool     `json:"completed"`
+	Failed    bool     `json:"failed"`
+	Skipped   bool     `json:"skipped"`
+	Panicked  bool     `json:"panicked"`
+	Panic     string   `json:"panic,omitempty"`
+	Helpers   []string `json:"helpers,omitempty"`
+	TempDirs  []string `json:"tempDirs,omitempty"`
+
+	// Only set by the parent process.
+	Exited   bool   `json:"exited"`
+	ExitCode int    `json:"exitCode"`
+	Stderr   []byte `json:"stderr,omitempty"`
+}
+
+// isolatedRecord is a single record written by the subprocess,
+// either a marker that the test started, a log, or the final result.
+type isolatedRecord struct {
+	Started bool            `json:"started,omitempty"`
+	Log     *Log            `json:"log,omitempty"`
+	Result  *isolatedResult `json:"result,omitempty"`
+}
+
+func (r isolatedResult) toTestResult() TestResult {
+	tb := newFakeTB(Opts{})
+	for i := range r.Logs {
+		tb.logs = append(tb.logs, logEntry{
+			resolved: &r.Logs[i],
+			kind:     r.Logs[i].Kind,
+			entry:    r.Logs[i].Message,
+		})
+	}
+
+	// A test that didn't complete is considered failed, similar to `go test`.
+	tb.failed = r.Failed || !r.Completed
+	tb.skipped = r.Skipped
+	tb.panicked = r.Panicked
+	if r.Panicked {
+		tb.recovered = r.Panic
+	}
+	tb.helperNames = r.Helpers
+	tb.tempDirs = r.TempDirs
+	tb.exited = r.Exited
+	tb.exitCode = r.ExitCode
+	tb.stderr = r.Stderr
+
+	tb.cancelCtx(nil)
+	close(tb.completed)
+	return TestResult{tb}
+}
+
+// isolatedCalls identifies each call to RunIsolated within a test.
+var isolatedCalls subproc.Calls
+
+// isolatedResultsByTest are the results of earlier calls in each running
+// test, which are removed when the test completes.
+var (
+	isolatedMu            sync.Mutex
+	isolatedResultsByTest = make(map[*testing.T][]isolatedResult)
+)
+
+func isolatedResults(t *testing.T) []isolatedResult {
+	isolatedMu.Lock()
+	defer isolatedMu.Unlock()
+
+	return isolatedResultsByTest[t]
+}
+
+func addIsolatedResult(t *testing.T, res isolatedResult) {
+	first := func() bool {
+		isolatedMu.Lock()
+		defer isolatedMu.Unlock()
+
+		isolatedResultsByTest[t] = append(isolatedResultsByTest[t], res)
+		return len(isolatedResultsByTest[t]) == 1
+	}()
+
+	if first {
+		t.Cleanup(func() {
+			isolatedMu.Lock()
+			defer isolatedMu.Unlock()
+
+			delete(isolatedResultsByTest, t)
+		})
+	}
+}
+
+// runIsolatedParent runs the call in a subprocess of the test binary.
+func runIsolatedParent(testName, call string, prev []isolatedResult) (isolatedResult, error) {
+	out, err := os.CreateTemp("", "faket-isolated-*.json")
+	if err != nil {
+		return isolatedResult{}, err
+	}
+	defer os.Remove(out.Name()) //nolint:errcheck // best-effort removal of temporary file.
+	defer out.Close()           //nolint:errcheck // only read from.
+
+	env := append(os.Environ(),
+		isolatedRunEnv+"="+call,
+		isolatedOutEnv+"="+out.Name(),
+	)
+
+	if len(prev) > 0 {
+		prevFile, err := writeIsolatedPrev(prev)
+		if err != nil {
+			return isolatedResult{}, err
+		}
+		defer os.Remove(prevFile) //nolint:errcheck // best-effort removal of temporary file.
+		env = append(env, isolatedPrevEnv+"="+prevFile)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(os.Args[0], subproc.Args(testName)...)
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); err != nil && !errors.As(err, &exitErr) {
+		return isolatedResult{}, err
+	}
+
+	res, started, err := readIsolatedRecords(out)
+	if err != nil {
+		return isolatedResult{}, err
+	}
+
+	res.ExitCode = cmd.ProcessState.ExitCode()
+	res.Stderr = stderr.Bytes()
+	if !started {
+		// The subprocess never reached the call to RunIsolated,
+		// such as when the test name doesn't match the run pattern.
+		return isolatedResult{}, fmt.Errorf("test did not run in subprocess\nstdout:\n%s\nstderr:\n%s", stdout.String(), stderr.String())
+	}
+	if !res.Completed {
+		// The test started but didn't complete, so the process exited,
+		// which includes os.Exit(0).
+		res.Exited = true
+	}
+	return res, nil
+}
+
+// readIsolatedRecords reads the records written by the subprocess,
+// and whether the test started. The process may have exited while writing,
+// so invalid records are ignored.
+func readIsolatedRecords(r io.Reader) (_ isolatedResult, started bool, _ error) {
+	var res isolatedResult
+	dec := json.NewDecoder(r)
+	for {
+		var rec isolatedRecord
+		if err := dec.Decode(&rec); err != nil {
+			var syntaxErr *json.SyntaxError
+			if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &syntaxErr) {
+				return res, started, nil
+			}
+			return res, started, err
+		}
+
+		if rec.Started {
+			started = true
+		}
+		if rec.Log != nil {
+			res.Logs = append(res.Logs, *rec.Log)
+		}
+		if rec.Result != nil {
+			logs := res.Logs
+			res = *rec.Result
+			res.Logs = logs
+		}
+	}
+}
+
+func writeIsolatedPrev(prev []isolatedResult) (string, error) {
+	f, err := os.CreateTemp("", "faket-isolated-prev-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck // close error is checked below for writes.
+
+	if err := json.NewEncoder(f).Encode(prev); err != nil {
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// runIsolatedChild runs in the subprocess. Earlier calls to RunIsolated
+// return the result from the parent, and the selected call runs the test
+// and writes the results, then skips the rest of the current test.
+func runIsolatedChild(t *testing.T, run bool, n int, testFn func(t testing.TB)) TestResult {
+	t.Helper()
+
+	if !run {
+		var prev []isolatedResult
+		if err := readJSONFile(os.Getenv(isolatedPrevEnv), &prev); err != nil || n > len(prev) {
+			t.Fatalf("failed to read result of earlier RunIsolated call %d: %v", n, err)
+		}
+		return prev[n-1].toTestResult()
+	}
+
+	out, err := os.OpenFile(os.Getenv(isolatedOutEnv), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("failed to open isolated output: %v", err)
+	}
+	defer out.Close() //nolint:errcheck // records are written unbuffered.
+
+	// Each record is written with a single write, so records are not lost
+	// if the process exits later.
+	var mu sync.Mutex
+	enc := json.NewEncoder(out)
+	write := func(rec isolatedRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := enc.Encode(rec); err != nil {
+			fmt.Fprintf(os.Stderr, "faket: failed to write isolated record: %v\n", err)
+		}
+	}
+
+	tb := newFakeTB(Opts{
+		Hooks: []Hooks{{
+			OnLog: func(_ testing.TB, l Log) {
+				write(isolatedRecord{Log: &l})
+			},
+		}},
+	})
+	write(isolatedRecord{Started: true})
+	tr := runTest(tb, testFn)
+
+	res := isolatedResult{
+		Completed: true,
+		Failed:    tb.Failed(),
+		Skipped:   tb.Skipped(),
+		Panicked:  tr.Panicked(),
+		Helpers:   tr.Helpers(),
+		TempDirs:  tr.TempDirs(),
+	}
+	if res.Panicked {
+		res.Panic = fmt.Sprint(tb.recovered)
+	}
+	write(isolatedRecord{Result: &res})
+
+	t.SkipNow()
+	return tr
+}
+
+func readJSONFile(name string, v any) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,116 @@
+package faket
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/prashantv/faket/internal/subproc"
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestRunIsolated(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			t.Log("log")
+			errorHelper(t, "failed")
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Equal(t, "Exited", tr.Exited(), false)
+		want.Equal(t, "ExitCode", tr.ExitCode(), 0)
+		want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log", "failed"})
+		want.DeepEqual(t, "Helpers", tr.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+
+		logs := tr.Logs()
+		want.Contains(t, "CallerFile", logs[1].CallerFile, "isolated_test.go")
+		want.Equal(t, "Kind", logs[1].Kind, LogKindError)
+		tr.MustReportFromCaller(t)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			t.Skip("skipped")
+		})
+		want.Equal(t, "Outcome", tr.Outcome(), OutcomeSkip)
+		want.Equal(t, "Exited", tr.Exited(), false)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			panic("boom")
+		})
+		want.Equal(t, "Panicked", tr.Panicked(), true)
+		want.Equal(t, "Exited", tr.Exited(), false)
+		tr.MustPanic(t, "boom")
+	})
+
+	t.Run("os.Exit", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			t.Log("before exit")
+			os.Exit(3)
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Equal(t, "Exited", tr.Exited(), true)
+		want.Equal(t, "ExitCode", tr.ExitCode(), 3)
+		want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"before exit"})
+	})
+
+	t.Run("os.Exit(0)", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			t.Log("before exit")
+			os.Exit(0)
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Equal(t, "Exited", tr.Exited(), true)
+		want.Equal(t, "ExitCode", tr.ExitCode(), 0)
+		want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"before exit"})
+	})
+
+	t.Run("log.Fatal", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			log.Fatal("fatal from helper")
+		})
+		want.Equal(t, "Exited", tr.Exited(), true)
+		want.Equal(t, "ExitCode", tr.ExitCode(), 1)
+		want.Contains(t, "Stderr", tr.Stderr(), "fatal from helper")
+	})
+
+	t.Run("panic in goroutine", func(t *testing.T) {
+		tr := RunIsolated(t, func(t testing.TB) {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				panic("goroutine boom")
+			}()
+			<-done
+		})
+		want.Equal(t, "Exited", tr.Exited(), true)
+		want.Equal(t, "ExitCode", tr.ExitCode(), 2)
+		want.Contains(t, "Stderr", tr.Stderr(), "panic: goroutine boom")
+	})
+
+	t.Run("multiple calls", func(t *testing.T) {
+		tr1 := RunIsolated(t, func(t testing.TB) {
+			t.Log("first")
+			os.Exit(1)
+		})
+		tr2 := RunIsolated(t, func(t testing.TB) {
+			t.Log("second")
+		})
+
+		want.Equal(t, "first Exited", tr1.Exited(), true)
+		want.DeepEqual(t, "first Messages", tr1.Logs().Messages(), []string{"first"})
+		want.Equal(t, "second Exited", tr2.Exited(), false)
+		want.DeepEqual(t, "second Messages", tr2.Logs().Messages(), []string{"second"})
+	})
+}
+
+func TestRunIsolatedCount(t *testing.T) {
+	// Each run of the test must identify calls from the start.
+	args := append(subproc.Args("TestRunIsolated/multiple_calls"), "-test.count=2", "-test.v")
+	out, err := exec.Command(os.Args[0], args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("test failed with -count=2: %v\n%s", err, out)
+	}
+}
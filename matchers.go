@@ -0,0 +1,291 @@
+package faket
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/prashantv/faket/internal/sliceutil"
+)
+
+// LogMatcher matches a single log entry.
+type LogMatcher interface {
+	// MatchLog reports whether the log matches.
+	MatchLog(l Log) bool
+
+	// String describes the matcher for failure messages.
+	String() string
+}
+
+type logMatcher struct {
+	desc  string
+	text  string // expected text used to show a diff against the closest log.
+	match func(Log) bool
+}
+
+func (m logMatcher) MatchLog(l Log) bool { return m.match(l) }
+func (m logMatcher) String() string      { return m.desc }
+
+// LogContains matches logs where the message contains `s`.
+func LogContains(s string) LogMatcher {
+	return logMatcher{
+		desc: fmt.Sprintf("contains %q", s),
+		text: s,
+		match: func(l Log) bool {
+			return strings.Contains(l.Message, s)
+		},
+	}
+}
+
+// LogEquals matches logs where the message is exactly `msg`.
+func LogEquals(msg string) LogMatcher {
+	return logMatcher{
+		desc: fmt.Sprintf("equals %q", msg),
+		text: msg,
+		match: func(l Log) bool {
+			return l.Message == msg
+		},
+	}
+}
+
+// LogRegexp matches logs where the message matches the regular expression `expr`.
+// It panics if `expr` cannot be parsed.
+func LogRegexp(expr string) LogMatcher {
+	re := regexp.MustCompile(expr)
+	return logMatcher{
+		desc: fmt.Sprintf("matches regexp %q", expr),
+		match: func(l Log) bool {
+			return re.MatchString(l.Message)
+		},
+	}
+}
+
+// LogOfKind matches logs of the given kind.
+func LogOfKind(kind LogKind) LogMatcher {
+	return logMatcher{
+		desc: fmt.Sprintf("kind %v", kind),
+		match: func(l Log) bool {
+			return l.Kind == kind
+		},
+	}
+}
+
+// LogFromFunc matches logs where the caller function is `fn`.
+// The function can be fully qualified (e.g., "example.com/pkg.TestFoo.func1")
+// or omit a prefix (e.g., "TestFoo.func1").
+func LogFromFunc(fn string) LogMatcher {
+	return logMatcher{
+		desc: fmt.Sprintf("from func %v", fn),
+		match: func(l Log) bool {
+			return funcMatches(l.CallerFunc, fn)
+		},
+	}
+}
+
+// funcMatches reports whether the fully qualified function `full`
+// matches `fn`, which may omit a prefix of the package path.
+func funcMatches(full, fn string) bool {
+	return full == fn || strings.HasSuffix(full, "."+fn) || strings.HasSuffix(full, "/"+fn)
+}
+
+// LogAt matches logs attributed to the given file and line.
+// The file can be a full path, or a path suffix such as the base name.
+func LogAt(file string, line int) LogMatcher {
+	return logMatcher{
+		desc: fmt.Sprintf("at %v:%v", file, line),
+		match: func(l Log) bool {
+			if l.CallerLine != line {
+				return false
+			}
+			callerFile := filepath.ToSlash(l.CallerFile)
+			return callerFile == file || strings.HasSuffix(callerFile, "/"+file)
+		},
+	}
+}
+
+// AllOf matches logs that match all of the given matchers.
+func AllOf(matchers ...LogMatcher) LogMatcher {
+	m := logMatcher{
+		desc: strings.Join(sliceutil.Map(matchers, LogMatcher.String), " and "),
+		match: func(l Log) bool {
+			for _, m := range matchers {
+				if !m.MatchLog(l) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+	for _, child := range matchers {
+		if lm, ok := child.(logMatcher); ok && lm.text != "" {
+			m.text = lm.text
+			break
+		}
+	}
+	return m
+}
+
+// MatchOpts are options to customize how logs are matched.
+type MatchOpts struct {
+	// Unordered allows matchers to match logs in any order.
+	// By default, matchers must match logs in the same order as the logs.
+	Unordered bool
+
+	// NoOtherFailures reports error, fatal and panic logs
+	// that aren't matched by any matcher.
+	NoOtherFailures bool
+
+	// NoOtherLogs reports logs of any kind that aren't matched by any matcher.
+	NoOtherLogs bool
+}
+
+// logMatches is the result of matching logs against matchers.
+type logMatches struct {
+	logs     Logs
+	matchers []LogMatcher
+
+	// matched[i] is the index of the log matched by matchers[i], or -1.
+	matched []int
+	// unexpected are indexes of logs that weren't matched,
+	// only set when using NoOtherFailures or NoOtherLogs.
+	unexpected []int
+}
+
+// matchLogs matches each matcher against a separate log.
+func matchLogs(logs Logs, matchers []LogMatcher, opts MatchOpts) logMatches {
+	res := logMatches{
+		logs:     logs,
+		matchers: matchers,
+	}
+	if opts.Unordered {
+		res.matched = matchUnordered(logs, matchers)
+	} else {
+		res.matched = matchOrdered(logs, matchers)
+	}
+
+	if opts.NoOtherFailures || opts.NoOtherLogs {
+		used := sliceutil.ToSet(res.matched)
+		for i, l := range logs {
+			if _, ok := used[i]; ok {
+				continue
+			}
+			if opts.NoOtherLogs || l.isFailure() {
+				res.unexpected = append(res.unexpected, i)
+			}
+		}
+	}
+
+	return res
+}
+
+// matchOrdered matches each matcher to the first matching log
+// after the log matched by the previous matcher.
+func matchOrdered(logs Logs, matchers []LogMatcher) []int {
+	matched := make([]int, len(matchers))
+	next := 0
+	for mi, m := range matchers {
+		matched[mi] = -1
+		for li := next; li < len(logs); li++ {
+			if m.MatchLog(logs[li]) {
+				matched[mi] = li
+				next = li + 1
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// matchUnordered finds the maximum number of matchers that can be matched
+// to separate logs using augmenting paths.
+func matchUnordered(logs Logs, matchers []LogMatcher) []int {
+	matches := make([][]bool, len(matchers))
+	for mi, m := range matchers {
+		matches[mi] = sliceutil.Map(logs, m.MatchLog)
+	}
+
+	// logOwner[li] is the matcher that matched logs[li], or -1.
+	logOwner := make([]int, len(logs))
+	for i := range logOwner {
+		logOwner[i] = -1
+	}
+
+	var assign func(mi int, visited []bool) bool
+	assign = func(mi int, visited []bool) bool {
+		for li := range logs {
+			if !matches[mi][li] || visited[li] {
+				continue
+			}
+			visited[li] = true
+			if logOwner[li] < 0 || assign(logOwner[li], visited) {
+				logOwner[li] = mi
+				return true
+			}
+		}
+		return false
+	}
+	for mi := range matchers {
+		assign(mi, make([]bool, len(logs)))
+	}
+
+	matched := make([]int, len(matchers))
+	for i := range matched {
+		matched[i] = -1
+	}
+	for li, mi := range logOwner {
+		if mi >= 0 {
+			matched[mi] = li
+		}
+	}
+	return matched
+}
+
+func (m logMatches) firstMatch(matcher LogMatcher) int {
+	for li, l := range m.logs {
+		if matcher.MatchLog(l) {
+			return li
+		}
+	}
+	return -1
+}
+
+func (m logMatches) ok() bool {
+	if len(m.unexpected) > 0 {
+		return false
+	}
+	for _, li := range m.matched {
+		if li < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes which matchers matched, and which didn't.
+func (m logMatches) String() string {
+	var buf strings.Builder
+	for mi, matcher := range m.matchers {
+		li := m.matched[mi]
+		if li >= 0 {
+			fmt.Fprintf(&buf, "  matched: %v\n", matcher)
+			fmt.Fprintf(&buf, "    got: %v", Logs{m.logs[li]})
+			continue
+		}
+
+		fmt.Fprintf(&buf, "  missing: %v\n", matcher)
+		if li := m.firstMatch(matcher); li >= 0 {
+			fmt.Fprintf(&buf, "    out of order or already matched: %v", Logs{m.logs[li]})
+			continue
+		}
+		if lm, ok := matcher.(logMatcher); ok && lm.text != "" {
+			if d := closestDiff(lm.text, m.logs.Messages()); d != "" {
+				buf.WriteString(indentLines(strings.TrimPrefix(d, "\n")))
+			}
+		}
+	}
+	for _, li := range m.unexpected {
+		fmt.Fprintf(&buf, "  unexpected: %v", Logs{m.logs[li]})
+	}
+	return buf.String()
+}
@@ -0,0 +1,164 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestLogMatchers(t *testing.T) {
+	l := Log{
+		Message:    "count: expected equal",
+		CallerFile: "/path/to/pkg/foo_test.go",
+		CallerLine: 12,
+		CallerFunc: "example.com/pkg.TestFoo.func1",
+		Kind:       LogKindError,
+	}
+
+	tests := []struct {
+		matcher LogMatcher
+		want    bool
+	}{
+		{LogContains("expected"), true},
+		{LogContains("unexpected"), false},
+		{LogEquals("count: expected equal"), true},
+		{LogEquals("count"), false},
+		{LogRegexp(`^count: \w+ equal$`), true},
+		{LogRegexp(`^expected`), false},
+		{LogOfKind(LogKindError), true},
+		{LogOfKind(LogKindFatal), false},
+		{LogFromFunc("example.com/pkg.TestFoo.func1"), true},
+		{LogFromFunc("TestFoo.func1"), true},
+		{LogFromFunc("TestFoo"), false},
+		{LogAt("foo_test.go", 12), true},
+		{LogAt("pkg/foo_test.go", 12), true},
+		{LogAt("/path/to/pkg/foo_test.go", 12), true},
+		{LogAt("o_test.go", 12), false},
+		{LogAt("foo_test.go", 13), false},
+		{AllOf(LogOfKind(LogKindError), LogContains("count")), true},
+		{AllOf(LogOfKind(LogKindFatal), LogContains("count")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.matcher.String(), func(t *testing.T) {
+			want.Equal(t, "MatchLog", tt.matcher.MatchLog(l), tt.want)
+		})
+	}
+}
+
+func TestMustFailWith(t *testing.T) {
+	fnTR := RunTest(func(t testing.TB) {
+		t.Log("starting")
+		t.Error("error 1")
+		t.Error("error 2")
+		t.Fatal("fatal")
+	})
+
+	tests := []struct {
+		name         string
+		opts         MatchOpts
+		matchers     []LogMatcher
+		wantFail     bool
+		wantContains []string
+	}{
+		{
+			name:     "no matchers",
+			matchers: nil,
+		},
+		{
+			name: "all in order",
+			matchers: []LogMatcher{
+				LogContains("error 1"),
+				LogContains("error 2"),
+				AllOf(LogOfKind(LogKindFatal), LogEquals("fatal")),
+			},
+		},
+		{
+			name: "out of order",
+			matchers: []LogMatcher{
+				LogContains("error 2"),
+				LogContains("error 1"),
+			},
+			wantFail: true,
+			wantContains: []string{
+				`matched: contains "error 2"`,
+				`missing: contains "error 1"`,
+				"out of order or already matched: matchers_test.go",
+			},
+		},
+		{
+			name: "out of order with unordered",
+			opts: MatchOpts{Unordered: true},
+			matchers: []LogMatcher{
+				LogContains("error 2"),
+				LogContains("error 1"),
+			},
+		},
+		{
+			name: "unordered needs reassignment",
+			opts: MatchOpts{Unordered: true},
+			matchers: []LogMatcher{
+				LogContains("error"),
+				LogContains("error 1"),
+			},
+		},
+		{
+			name: "counts are exact",
+			opts: MatchOpts{Unordered: true},
+			matchers: []LogMatcher{
+				LogOfKind(LogKindError),
+				LogOfKind(LogKindError),
+				LogOfKind(LogKindError),
+			},
+			wantFail:     true,
+			wantContains: []string{"missing: kind error"},
+		},
+		{
+			name:         "missing shows closest log",
+			matchers:     []LogMatcher{LogContains("error 3")},
+			wantFail:     true,
+			wantContains: []string{"closest log diff (-want +got):\n        -error 3\n        +error 1\n"},
+		},
+		{
+			name:     "no other failures",
+			opts:     MatchOpts{NoOtherFailures: true},
+			matchers: []LogMatcher{LogContains("error 1")},
+			wantFail: true,
+			wantContains: []string{
+				`matched: contains "error 1"`,
+				"unexpected: matchers_test.go",
+				": error 2\n",
+				": fatal\n",
+			},
+		},
+		{
+			name: "no other failures ignores logs",
+			opts: MatchOpts{NoOtherFailures: true},
+			matchers: []LogMatcher{
+				LogRegexp("error [12]"),
+				LogRegexp("error [12]"),
+				LogOfKind(LogKindFatal),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := RunTest(func(t testing.TB) {
+				fnTR.MustFailWithOpts(t, tt.opts, tt.matchers...)
+			})
+			want.Equal(t, "Failed", tr.Failed(), tt.wantFail)
+			for _, s := range tt.wantContains {
+				want.Contains(t, "Message", tr.Logs().String(), s)
+			}
+		})
+	}
+
+	t.Run("passing test", func(t *testing.T) {
+		tr := RunTest(func(t testing.TB) {
+			RunTest(func(testing.TB) {}).MustFailWith(t)
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Contains(t, "Message", tr.Logs().String(), "test passed, but expected to fail")
+	})
+}
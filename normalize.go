@@ -0,0 +1,128 @@
+package faket
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prashantv/faket/internal/sliceutil"
+)
+
+// Normalizer rewrites values in log messages that vary between runs,
+// machines or Go versions, so logs can be compared against golden strings.
+type Normalizer struct {
+	// TempDirs are replaced with "$TEMPDIR<n>", where n is the 1-based
+	// index of the directory. See [TestResult.TempDirs].
+	TempDirs []string
+
+	// HexAddrs replaces hex values, such as pointers, with "0x0000".
+	HexAddrs bool
+
+	// Durations replaces durations, such as "1.5s" or "1m30s", with "0s".
+	Durations bool
+
+	// GoroutineIDs replaces "goroutine <id>" with "goroutine 1".
+	GoroutineIDs bool
+
+	// LineNumbers replaces line numbers in "<file>.go:<line>" with 0.
+	// When normalizing [Logs], the CallerLine is also set to 0.
+	LineNumbers bool
+
+	// Rules are custom rewrites, applied in order after the above.
+	Rules []NormalizeRule
+}
+
+// NormalizeRule rewrites a log message.
+type NormalizeRule func(string) string
+
+var (
+	hexAddrRE     = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+	goroutineIDRE = regexp.MustCompile(`goroutine [0-9]+`)
+	durationRE    = regexp.MustCompile(`\b(?:[0-9]+(?:\.[0-9]+)?(?:ns|us|µs|ms|h|m|s))+\b`)
+	lineNumberRE  = regexp.MustCompile(`(\.go):[0-9]+`)
+)
+
+// ReplaceRegexp returns a rule that replaces matches of the regular expression
+// `expr` with `repl`, as [regexp.Regexp.ReplaceAllString].
+// It panics if `expr` cannot be parsed.
+func ReplaceRegexp(expr, repl string) NormalizeRule {
+	re := regexp.MustCompile(expr)
+	return func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	}
+}
+
+// ReplaceString returns a rule that replaces all instances of `old` with `repl`.
+func ReplaceString(old, repl string) NormalizeRule {
+	return func(s string) string {
+		return strings.ReplaceAll(s, old, repl)
+	}
+}
+
+// Normalizer returns a [Normalizer] with all built-in rules enabled,
+// including replacing the temporary directories created by the test.
+func (r TestResult) Normalizer() Normalizer {
+	return Normalizer{
+		TempDirs:     r.TempDirs(),
+		HexAddrs:     true,
+		Durations:    true,
+		GoroutineIDs: true,
+		LineNumbers:  true,
+	}
+}
+
+// Normalize applies the normalizer's rules to `s`.
+func (n Normalizer) Normalize(s string) string {
+	s = n.replaceTempDirs(s)
+	if n.HexAddrs {
+		s = hexAddrRE.ReplaceAllString(s, "0x0000")
+	}
+	if n.GoroutineIDs {
+		s = goroutineIDRE.ReplaceAllString(s, "goroutine 1")
+	}
+	if n.Durations {
+		s = durationRE.ReplaceAllString(s, "0s")
+	}
+	if n.LineNumbers {
+		s = lineNumberRE.ReplaceAllString(s, "$1:0")
+	}
+	for _, rule := range n.Rules {
+		s = rule(s)
+	}
+	return s
+}
+
+func (n Normalizer) replaceTempDirs(s string) string {
+	if len(n.TempDirs) == 0 {
+		return s
+	}
+
+	// Replace longer paths first, so a directory that is a prefix
+	// of another directory doesn't replace part of the longer path.
+	idxs := make([]int, len(n.TempDirs))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return len(n.TempDirs[idxs[i]]) > len(n.TempDirs[idxs[j]])
+	})
+
+	for _, i := range idxs {
+		if d := n.TempDirs[i]; d != "" {
+			s = strings.ReplaceAll(s, d, fmt.Sprintf("$TEMPDIR%d", i+1))
+		}
+	}
+	return s
+}
+
+// Normalize returns a copy of the logs with messages rewritten by `n`.
+func (ls Logs) Normalize(n Normalizer) Logs {
+	return sliceutil.Map(ls, func(l Log) Log {
+		l.Message = n.Normalize(l.Message)
+		if n.LineNumbers {
+			l.CallerLine = 0
+		}
+		return l
+	})
+}
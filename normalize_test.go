@@ -0,0 +1,94 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestNormalizer(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Normalizer
+		in   string
+		want string
+	}{
+		{
+			name: "no rules",
+			in:   "0x1234 took 1.5s in goroutine 7 at foo.go:12",
+			want: "0x1234 took 1.5s in goroutine 7 at foo.go:12",
+		},
+		{
+			name: "hex addrs",
+			n:    Normalizer{HexAddrs: true},
+			in:   "ptr 0xc000012345, 0xDEADbeef",
+			want: "ptr 0x0000, 0x0000",
+		},
+		{
+			name: "durations",
+			n:    Normalizer{Durations: true},
+			in:   "took 1.5s, then 3ms, then 1h2m3.25s, then 10µs (0.00s)",
+			want: "took 0s, then 0s, then 0s, then 0s (0s)",
+		},
+		{
+			name: "durations ignores words",
+			n:    Normalizer{Durations: true},
+			in:   "retried 5 times in 2min, v1s",
+			want: "retried 5 times in 2min, v1s",
+		},
+		{
+			name: "goroutine IDs",
+			n:    Normalizer{GoroutineIDs: true},
+			in:   "goroutine 123 [running]:",
+			want: "goroutine 1 [running]:",
+		},
+		{
+			name: "line numbers",
+			n:    Normalizer{LineNumbers: true},
+			in:   "\t/usr/local/go/src/runtime/panic.go:636 +0x12\nfoo_test.go:12: msg",
+			want: "\t/usr/local/go/src/runtime/panic.go:0 +0x12\nfoo_test.go:0: msg",
+		},
+		{
+			name: "temp dirs",
+			n:    Normalizer{TempDirs: []string{"/tmp/a", "/tmp/a1"}},
+			in:   "wrote /tmp/a1/f and /tmp/a/f",
+			want: "wrote $TEMPDIR2/f and $TEMPDIR1/f",
+		},
+		{
+			name: "custom rules in order",
+			n: Normalizer{
+				HexAddrs: true,
+				Rules: []NormalizeRule{
+					ReplaceString("0x0000", "PTR"),
+					ReplaceRegexp(`id=[0-9]+`, "id=ID"),
+				},
+			},
+			in:   "id=42 at 0x1f",
+			want: "id=ID at PTR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want.Equal(t, "Normalize", tt.n.Normalize(tt.in), tt.want)
+		})
+	}
+}
+
+func TestLogsNormalize(t *testing.T) {
+	tr := RunTest(func(t testing.TB) {
+		d := t.TempDir()
+		t.Logf("created %v at %p", d, t)
+	})
+	tr.MustPass(t)
+
+	want.Equal(t, "TempDirs", len(tr.TempDirs()), 1)
+
+	logs := tr.Logs().Normalize(tr.Normalizer())
+	want.DeepEqual(t, "Messages", logs.Messages(), []string{"created $TEMPDIR1 at 0x0000"})
+	want.Equal(t, "CallerLine", logs[0].CallerLine, 0)
+	want.Equal(t, "String", logs.String(), "normalize_test.go:0: created $TEMPDIR1 at 0x0000\n")
+
+	// The original logs should not be modified.
+	want.NotContains(t, "original logs", tr.Logs().String(), "$TEMPDIR1")
+}
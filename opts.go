@@ -0,0 +1,100 @@
+package faket
+
+import (
+	"context"
+	"testing"
+)
+
+// Opts are options for customizing the fake [testing.TB] used by [RunTestOpts].
+type Opts struct {
+	// EnvIsolation controls how Setenv and Chdir are handled when multiple
+	// fakes run concurrently. By default, there's no isolation.
+	EnvIsolation EnvIsolation
+
+	// Audit enables checking for changes to the environment, working
+	// directory and AuditDirs that are not restored by the end of the test,
+	// see [TestResult.SideEffects].
+	//
+	// Changes made by other code running concurrently are also reported.
+	Audit bool
+
+	// AuditDirs are directories to check for file changes when Audit is set.
+	AuditDirs []string
+
+	// Faults are failures to inject into operations such as TempDir.
+	Faults []Fault
+
+	// Hooks are called as the test runs, in the order they're specified.
+	Hooks []Hooks
+
+	// Behaviors replace the default implementations of some methods.
+	Behaviors Behaviors
+
+	// Interceptors intercept each call to the fake's methods by the test,
+	// including calls by Hooks and Behaviors, and logs with a caller location
+	// resolved elsewhere (such as by ReplayTo, ForwardTo or Spy).
+	// The first interceptor is the outermost, and is called first.
+	Interceptors []Interceptor
+
+	// RecordCalls records each call to the fake's methods by the test,
+	// see [TestResult.Calls] and [ExpectCalls].
+	RecordCalls bool
+
+	// CaptureOutput captures output written to os.Stdout, os.Stderr and
+	// the standard logger while the test and its cleanups run,
+	// see [TestResult.Stdout] and [TestResult.Stderr].
+	//
+	// Output written by other code running concurrently is also captured.
+	// If multiple fakes capture output at the same time, output is captured
+	// by the fake that started capturing most recently, and the original
+	// output is restored once every fake has stopped capturing.
+	CaptureOutput bool
+}
+
+// Behaviors replace faket's default implementations of [testing.TB] methods
+// that interact with the process. Logging, helpers and cleanups still use faket,
+// so implementations can use the passed in fake to add cleanups or fail the test.
+// Calls to the passed in fake go through any [Opts.Interceptors].
+//
+// Fields that are nil use the default implementation. EnvIsolation and Faults
+// only apply to default implementations.
+type Behaviors struct {
+	// Name returns the test name, which defaults to "faket-no-name".
+	Name func() string
+
+	// Context returns the parent of the test's context, which defaults to
+	// [context.Background]. The test's context is still cancelled before
+	// cleanups run.
+	Context func() context.Context
+
+	// TempDir replaces [testing.TB].TempDir.
+	TempDir func(t testing.TB) string
+
+	// Setenv replaces [testing.TB].Setenv.
+	Setenv func(t testing.TB, key, value string)
+
+	// Chdir replaces [testing.TB].Chdir.
+	Chdir func(t testing.TB, dir string)
+}
+
+// EnvIsolation controls the handling of concurrent fakes that modify
+// the process environment or working directory using Setenv or Chdir.
+type EnvIsolation int
+
+// EnvIsolation values.
+const (
+	// EnvIsolationNone allows concurrent fakes to modify the process,
+	// which may cause them to interfere with each other.
+	EnvIsolationNone EnvIsolation = iota
+	// EnvIsolationDetect fails the test if it calls Setenv or Chdir while
+	// another isolated fake has called Setenv or Chdir and not yet completed.
+	// This is similar to the real testing package which panics if Setenv
+	// or Chdir are used in parallel tests.
+	EnvIsolationDetect
+	// EnvIsolationSerialize blocks Setenv or Chdir until any other isolated
+	// fake that has called Setenv or Chdir has completed. If that fake started
+	// this fake from its test or cleanup goroutine, directly or through other
+	// isolated fakes, waiting would deadlock, so the test fails as with
+	// EnvIsolationDetect.
+	EnvIsolationSerialize
+)
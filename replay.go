@@ -0,0 +1,32 @@
+package faket
+
+import "testing"
+
+// ReplayTo reports the test's result to `t`, as if the test ran using `t`.
+//
+// Each log is logged to `t` at its original caller location (see [Spy] for
+// limitations before Go 1.25), then `t` is failed or skipped to match the test's
+// result. If the test panicked, ReplayTo panics with the same value.
+//
+// Unlike the original test, failures from Fatal don't stop `t`.
+func (r TestResult) ReplayTo(t testing.TB) {
+	t.Helper()
+
+	for _, l := range r.Logs() {
+		// The panic log is added by faket, and is replaced by panicking below.
+		if l.Kind == LogKindPanic {
+			continue
+		}
+		logTo(t, l)
+	}
+
+	if r.Panicked() {
+		panic(r.res.recovered)
+	}
+	if r.res.Failed() {
+		t.Fail()
+	}
+	if r.res.Skipped() {
+		t.SkipNow()
+	}
+}
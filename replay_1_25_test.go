@@ -0,0 +1,21 @@
+//go:build go1.25
+
+package faket_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket"
+	"github.com/prashantv/faket/exp/cmpt"
+)
+
+func TestReplayToMatchesRealLogs(t *testing.T) {
+	orig := faket.RunTest(func(t testing.TB) {
+		t.Log("log")
+		spyHelper(t, "multi-line\nerror")
+	})
+
+	cmpt.Compare(t, func(t testing.TB) {
+		orig.ReplayTo(t)
+	})
+}
@@ -0,0 +1,81 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestReplayTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          func(t testing.TB)
+		wantOutcome Outcome
+	}{
+		{
+			name: "pass",
+			fn: func(t testing.TB) {
+				t.Log("log")
+			},
+			wantOutcome: OutcomePass,
+		},
+		{
+			name: "fail",
+			fn: func(t testing.TB) {
+				t.Log("log")
+				errorHelper(t, "failed")
+				t.Fatal("fatal")
+			},
+			wantOutcome: OutcomeFail,
+		},
+		{
+			name: "skip",
+			fn: func(t testing.TB) {
+				t.Skip("skipped")
+			},
+			wantOutcome: OutcomeSkip,
+		},
+		{
+			name: "fail then skip",
+			fn: func(t testing.TB) {
+				t.Error("failed")
+				t.Skip("skipped")
+			},
+			wantOutcome: OutcomeFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := RunTest(tt.fn)
+
+			var afterReplay bool
+			tr := RunTest(func(t testing.TB) {
+				orig.ReplayTo(t)
+				afterReplay = true
+			})
+
+			want.Equal(t, "Outcome", tr.Outcome(), tt.wantOutcome)
+			want.Equal(t, "FailedAndSkipped", tr.FailedAndSkipped(), orig.FailedAndSkipped())
+			want.DeepEqual(t, "Logs", tr.Logs(), orig.Logs())
+			want.Equal(t, "continued after replay", afterReplay, !orig.res.Skipped())
+		})
+	}
+}
+
+func TestReplayToPanic(t *testing.T) {
+	orig := RunTest(func(t testing.TB) {
+		t.Log("log")
+		panic("boom")
+	})
+
+	tr := RunTest(func(t testing.TB) {
+		orig.ReplayTo(t)
+	})
+
+	tr.MustPanic(t, "boom")
+	want.Equal(t, "Panicked", tr.Panicked(), true)
+	want.Equal(t, "recovered", tr.res.recovered, orig.res.recovered)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log", "panic: boom"})
+	want.DeepEqual(t, "first log", tr.Logs()[0], orig.Logs()[0])
+}
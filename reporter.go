@@ -0,0 +1,30 @@
+package faket
+
+import (
+	"reflect"
+	"testing"
+)
+
+// RunReporter runs the given function using a fake [testing.TB] as T, and
+// returns the result. T is an interface implemented by [testing.TB], such as
+// the minimal interfaces accepted by assertion and mocking libraries
+// (e.g., an interface with Errorf and FailNow).
+//
+// If the fake doesn't implement T, the test fails without calling `fn`.
+func RunReporter[T any](fn func(t T)) TestResult {
+	return RunReporterOpts(Opts{}, fn)
+}
+
+// RunReporterOpts is the same as RunReporter, but with options to customize
+// the fake [testing.TB].
+func RunReporterOpts[T any](opts Opts, fn func(t T)) TestResult {
+	tb := newFakeTB(opts)
+	tb.testFn = reflect.ValueOf(fn).Pointer()
+	return runTest(tb, func(t testing.TB) {
+		reporter, ok := t.(T)
+		if !ok {
+			t.Fatalf("faket: fake testing.TB does not implement %v", reflect.TypeFor[T]())
+		}
+		fn(reporter)
+	})
+}
@@ -0,0 +1,91 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+// Minimal interfaces similar to those accepted by assertion libraries.
+type (
+	errorReporter interface {
+		Errorf(format string, args ...any)
+		FailNow()
+	}
+
+	helperReporter interface {
+		errorReporter
+		Helper()
+	}
+
+	cleanupReporter interface {
+		helperReporter
+		Cleanup(func())
+		Name() string
+	}
+
+	parallelReporter interface {
+		Parallel()
+	}
+)
+
+// assertEqual is a custom assertion that supports reporters without Helper.
+func assertEqual(t errorReporter, got, want int) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+		t.FailNow()
+	}
+}
+
+func TestRunReporter(t *testing.T) {
+	t.Run("minimal", func(t *testing.T) {
+		var continued bool
+		tr := RunReporter(func(t errorReporter) {
+			assertEqual(t, 1, 2)
+			continued = true
+		})
+
+		want.Equal(t, "continued after FailNow", continued, false)
+		want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"got 1, want 2"})
+		tr.MustReportFromCaller(t)
+	})
+
+	t.Run("helper", func(t *testing.T) {
+		tr := RunReporter(func(t helperReporter) {
+			assertEqual(t, 1, 2)
+		})
+		tr.MustReportFromCaller(t)
+	})
+
+	t.Run("cleanup and name", func(t *testing.T) {
+		var cleaned bool
+		var name string
+		tr := RunReporterOpts(Opts{
+			Behaviors: Behaviors{Name: func() string { return "TestName" }},
+		}, func(t cleanupReporter) {
+			t.Cleanup(func() { cleaned = true })
+			name = t.Name()
+			assertEqual(t, 1, 1)
+		})
+
+		tr.MustPass(t)
+		want.Equal(t, "cleaned", cleaned, true)
+		want.Equal(t, "Name", name, "TestName")
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+		var called bool
+		tr := RunReporter(func(t parallelReporter) {
+			called = true
+		})
+
+		want.Equal(t, "called", called, false)
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{
+			"faket: fake testing.TB does not implement faket.parallelReporter",
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package faket
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// sourceFiles caches the lines of source files, keyed by the file path.
+var sourceFiles sync.Map
+
+// sourceLines returns the lines of the given source file.
+func sourceLines(file string) ([]string, error) {
+	if lines, ok := sourceFiles.Load(file); ok {
+		return lines.([]string), nil
+	}
+
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(contents), "\n")
+	sourceFiles.Store(file, lines)
+	return lines, nil
+}
+
+// sourceLine returns the 1-based line from the given source file.
+func sourceLine(file string, line int) (string, error) {
+	lines, err := sourceLines(file)
+	if err != nil {
+		return "", err
+	}
+	if line < 1 || line > len(lines) {
+		return "", nil
+	}
+	return lines[line-1], nil
+}
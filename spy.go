@@ -0,0 +1,216 @@
+package faket
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prashantv/faket/internal/tblog"
+)
+
+// Spy returns a [testing.TB] that forwards calls to `t`, while recording
+// logs, failures, skips and helpers the same as [RunTest]. The returned
+// function returns the result recorded so far.
+//
+// Logs are forwarded to `t` at the caller location after skipping helpers
+// that called Helper on the spy. Before Go 1.25, the testing package doesn't
+// support logging with a different location, so logs are attributed to the
+// direct caller of the spy method.
+func Spy(t testing.TB) (testing.TB, func() TestResult) {
+	_, resolves := t.(resolvedLogger)
+	s := &spyTB{
+		TB:         t,
+		rec:        newFakeTB(Opts{}),
+		markFrames: !resolves,
+	}
+	return s, func() TestResult { return TestResult{s.rec} }
+}
+
+// spyTB embeds the spied testing.TB, so methods that aren't recorded
+// are forwarded as-is.
+type spyTB struct {
+	testing.TB
+
+	rec *fakeTB
+
+	// markFrames is set if spy methods should call Helper on TB,
+	// so logs aren't attributed to the spy.
+	markFrames bool
+}
+
+// resolvedLogger is implemented by fakes that can record logs with
+// a caller location resolved by another fake.
+type resolvedLogger interface {
+	logResolved(l Log)
+}
+
+var (
+	_ resolvedLogger = (*fakeTB)(nil)
+	_ resolvedLogger = (*spyTB)(nil)
+)
+
+// helperMarker is implemented by fakes that record helpers.
+type helperMarker interface {
+	markHelper(pc uintptr)
+}
+
+var (
+	_ helperMarker = (*fakeTB)(nil)
+	_ helperMarker = (*spyTB)(nil)
+)
+
+// logTo logs l to t, attributed to the caller location of l.
+func logTo(t testing.TB, l Log) {
+	if rl, ok := t.(resolvedLogger); ok {
+		rl.logResolved(l)
+		return
+	}
+
+	t.Helper()
+	tblog.Log(t, l.CallerFile, l.CallerLine, l.Message)
+}
+
+var (
+	_ tblog.Recorder = (*fakeTB)(nil)
+	_ tblog.Recorder = (*spyTB)(nil)
+)
+
+// RecordLog records a log from other packages in this module, see tblog.LogTo.
+func (tb *fakeTB) RecordLog(e tblog.Entry) {
+	tb.logResolved(entryToLog(e))
+}
+
+// RecordLog records and forwards a log from other packages in this module.
+func (s *spyTB) RecordLog(e tblog.Entry) {
+	s.logResolved(entryToLog(e))
+}
+
+func entryToLog(e tblog.Entry) Log {
+	return Log{
+		Message:    e.Message,
+		CallerFile: e.File,
+		CallerLine: e.Line,
+		CallerFunc: e.Func,
+		Kind:       LogKindLog,
+	}
+}
+
+func (s *spyTB) logResolved(l Log) {
+	s.rec.logResolved(l)
+	logTo(s.TB, l)
+}
+
+func (s *spyTB) log(callers []uintptr, kind LogKind, msg string) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	e := s.rec.log(callers, kind, msg)
+	logTo(s.TB, s.rec.toLog(e))
+}
+
+func (s *spyTB) Log(args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindLog, sprintln(args...))
+}
+
+func (s *spyTB) Logf(format string, args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindLog, fmt.Sprintf(format, args...))
+}
+
+func (s *spyTB) Error(args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindError, sprintln(args...))
+	s.Fail()
+}
+
+func (s *spyTB) Errorf(format string, args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindError, fmt.Sprintf(format, args...))
+	s.Fail()
+}
+
+func (s *spyTB) Fatal(args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindFatal, sprintln(args...))
+	s.FailNow()
+}
+
+func (s *spyTB) Fatalf(format string, args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindFatal, fmt.Sprintf(format, args...))
+	s.FailNow()
+}
+
+func (s *spyTB) Skip(args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindSkip, sprintln(args...))
+	s.SkipNow()
+}
+
+func (s *spyTB) Skipf(format string, args ...interface{}) {
+	if s.markFrames {
+		s.TB.Helper()
+	}
+	s.log(getCallers(withSelf), LogKindSkip, fmt.Sprintf(format, args...))
+	s.SkipNow()
+}
+
+func (s *spyTB) Fail() {
+	s.rec.Fail()
+	s.TB.Fail()
+}
+
+func (s *spyTB) FailNow() {
+	s.rec.Fail()
+	s.TB.FailNow()
+}
+
+func (s *spyTB) SkipNow() {
+	func() {
+		s.rec.mu.Lock()
+		defer s.rec.mu.Unlock()
+
+		s.rec.skipped = true
+	}()
+	s.TB.SkipNow()
+}
+
+func (s *spyTB) Helper() {
+	callerPC := getCaller(skipSelf)
+	if callerPC == 0 {
+		return
+	}
+
+	s.markHelper(callerPC)
+}
+
+func (s *spyTB) markHelper(pc uintptr) {
+	s.rec.markHelper(pc)
+	if hm, ok := s.TB.(helperMarker); ok {
+		hm.markHelper(pc)
+	}
+}
+
+func (s *spyTB) TempDir() string {
+	d := s.TB.TempDir()
+
+	s.rec.mu.Lock()
+	defer s.rec.mu.Unlock()
+
+	s.rec.tempDirs = append(s.rec.tempDirs, d)
+	return d
+}
@@ -0,0 +1,23 @@
+//go:build go1.25
+
+package faket_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket"
+	"github.com/prashantv/faket/exp/cmpt"
+)
+
+func TestSpyMatchesRealLogs(t *testing.T) {
+	cmpt.Compare(t, func(t testing.TB) {
+		st, _ := faket.Spy(t)
+		st.Log("log")
+		spyHelper(st, "multi-line\nerror")
+	})
+}
+
+func spyHelper(t testing.TB, msg string) {
+	t.Helper()
+	t.Error(msg)
+}
@@ -0,0 +1,69 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestSpy(t *testing.T) {
+	tr := RunTest(func(t testing.TB) {
+		st, _ := Spy(t)
+		st.Log("log")
+		errorHelper(st, "failed")
+		st.Fatal("fatal")
+		t.Error("unreachable")
+	})
+
+	// The spied test fails, and forwards logs with the original callers.
+	want.Equal(t, "Failed", tr.Failed(), true)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"log", "failed", "fatal"})
+	want.DeepEqual(t, "Helpers", tr.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+	for _, l := range tr.Logs() {
+		want.Equal(t, "CallerFunc", l.CallerFunc, "github.com/prashantv/faket.TestSpy.func1")
+	}
+}
+
+func TestSpyResult(t *testing.T) {
+	var spied TestResult
+	tr := RunTest(func(t testing.TB) {
+		st, result := Spy(t)
+		defer func() {
+			spied = result()
+		}()
+
+		st.Log("log")
+		errorHelper(st, "failed")
+		st.TempDir()
+		st.Skip("skipped")
+	})
+
+	want.Equal(t, "Outcome", tr.Outcome(), OutcomeFail)
+	want.Equal(t, "spied Outcome", spied.Outcome(), OutcomeFail)
+	want.Equal(t, "spied FailedAndSkipped", spied.FailedAndSkipped(), true)
+	want.DeepEqual(t, "spied Helpers", spied.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+	want.Equal(t, "spied TempDirs", len(spied.TempDirs()), 1)
+	want.DeepEqual(t, "spied logs", spied.Logs(), tr.Logs())
+
+	logs := spied.Logs()
+	want.DeepEqual(t, "spied Messages", logs.Messages(), []string{"log", "failed", "skipped"})
+	want.Equal(t, "TBFunc", logs[0].TBFunc, "github.com/prashantv/faket.(*spyTB).Log")
+	want.Equal(t, "Kind", logs[1].Kind, LogKindError)
+}
+
+func TestSpyNested(t *testing.T) {
+	var inner, outer TestResult
+	tr := RunTest(func(t testing.TB) {
+		st1, result1 := Spy(t)
+		st2, result2 := Spy(st1)
+		errorHelper(st2, "failed")
+		inner, outer = result2(), result1()
+	})
+
+	for _, r := range []TestResult{tr, inner, outer} {
+		want.Equal(t, "Failed", r.Failed(), true)
+		want.DeepEqual(t, "Messages", r.Logs().Messages(), []string{"failed"})
+		want.DeepEqual(t, "Helpers", r.Helpers(), []string{"github.com/prashantv/faket.errorHelper"})
+		want.Equal(t, "CallerFunc", r.Logs()[0].CallerFunc, "github.com/prashantv/faket.TestSpyNested.func1")
+	}
+}
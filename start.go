@@ -0,0 +1,51 @@
+package faket
+
+import "testing"
+
+// Run is a handle to a test started using [Start].
+type Run struct {
+	tb *fakeTB
+}
+
+// Start starts running the given test using a fake [testing.TB], and returns
+// a handle to the running test without waiting for it to complete.
+//
+// This is useful for testing helpers that block until their context
+// is cancelled, see [Run.Cancel].
+func Start(testFn func(t testing.TB)) *Run {
+	return StartOpts(Opts{}, testFn)
+}
+
+// StartOpts is the same as Start, but with options to customize
+// the fake [testing.TB].
+func StartOpts(opts Opts, testFn func(t testing.TB)) *Run {
+	tb := newFakeTB(opts)
+	startTest(tb, testFn)
+	return &Run{tb}
+}
+
+// Done returns a channel that's closed when the test completes,
+// after all cleanups have run.
+func (r *Run) Done() <-chan struct{} {
+	return r.tb.completed
+}
+
+// Wait waits for the test to complete, and returns the result.
+func (r *Run) Wait() TestResult {
+	<-r.tb.completed
+	return TestResult{r.tb}
+}
+
+// Snapshot returns the logs recorded so far.
+func (r *Run) Snapshot() Logs {
+	return r.tb.resolvedLogs()
+}
+
+// Cancel cancels the test's [testing.TB].Context with the given cause,
+// which is returned by [context.Cause]. If cause is nil, it's set to
+// [context.Canceled].
+//
+// Cancel doesn't stop the test, it only signals helpers using the context.
+func (r *Run) Cancel(cause error) {
+	r.tb.cancelCtx(cause)
+}
@@ -0,0 +1,35 @@
+//go:build go1.24
+
+package faket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestStartCancel(t *testing.T) {
+	errStop := errors.New("stop")
+
+	run := Start(func(t testing.TB) {
+		<-t.Context().Done()
+		t.Logf("stopped: %v, cause: %v", t.Context().Err(), context.Cause(t.Context()))
+	})
+
+	run.Cancel(errStop)
+	tr := run.Wait()
+	tr.MustPass(t)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"stopped: context canceled, cause: stop"})
+}
+
+func TestStartCancelNilCause(t *testing.T) {
+	run := Start(func(t testing.TB) {
+		<-t.Context().Done()
+		t.Log("cause:", context.Cause(t.Context()))
+	})
+
+	run.Cancel(nil)
+	want.DeepEqual(t, "Messages", run.Wait().Logs().Messages(), []string{"cause: context canceled"})
+}
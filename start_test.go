@@ -0,0 +1,43 @@
+package faket
+
+import (
+	"testing"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+func TestStart(t *testing.T) {
+	logged := make(chan struct{})
+	release := make(chan struct{})
+	run := Start(func(t testing.TB) {
+		t.Log("started")
+		close(logged)
+		<-release
+		t.Error("failed")
+	})
+
+	<-logged
+	want.DeepEqual(t, "Snapshot", run.Snapshot().Messages(), []string{"started"})
+	select {
+	case <-run.Done():
+		t.Fatalf("test should not be done until released")
+	default:
+	}
+
+	close(release)
+	<-run.Done()
+	tr := run.Wait()
+	want.Equal(t, "Failed", tr.Failed(), true)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"started", "failed"})
+	want.DeepEqual(t, "Snapshot after completion", run.Snapshot(), tr.Logs())
+}
+
+func TestStartOpts(t *testing.T) {
+	var events []string
+	run := StartOpts(Opts{Hooks: []Hooks{recordHooks("", &events)}}, func(t testing.TB) {
+		t.Log("log")
+	})
+
+	run.Wait().MustPass(t)
+	want.DeepEqual(t, "events", events, []string{"log log: log"})
+}
@@ -0,0 +1,83 @@
+//go:build go1.25
+
+package faket
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+// RunSynctest runs the given test using a fake [testing.TB] inside a
+// [testing/synctest] bubble started using `t`, and returns the result.
+//
+// The test and its cleanups run in the bubble, so time advances instantly
+// when all goroutines in the bubble are blocked, and the test can use
+// [synctest.Wait]. The fake's Context is created in the bubble, and if `t` has
+// a deadline, the Context has a deadline the same duration away on the
+// bubble's clock.
+//
+// As with [synctest.Test], goroutines started by the test must exit
+// before RunSynctest returns, otherwise `t` fails.
+func RunSynctest(t *testing.T, testFn func(t testing.TB)) TestResult {
+	t.Helper()
+
+	return RunSynctestOpts(t, Opts{}, testFn)
+}
+
+// RunSynctestOpts is the same as RunSynctest, but with options to customize
+// the fake [testing.TB].
+//
+// With [Opts.CaptureOutput], output is captured outside of the bubble, since
+// goroutines copying output are blocked on I/O, which would stop time from
+// advancing in the bubble.
+func RunSynctestOpts(t *testing.T, opts Opts, testFn func(t testing.TB)) TestResult {
+	t.Helper()
+
+	var capture *outputCapture
+	if opts.CaptureOutput {
+		var err error
+		capture, err = startCapture()
+		if err != nil {
+			t.Fatalf("faket: cannot capture output: %v", err)
+		}
+	}
+
+	// The deadline is converted to a duration using the real clock,
+	// as time inside the bubble starts at a fixed point.
+	deadline, hasDeadline := t.Deadline()
+	remaining := time.Until(deadline)
+
+	var tr TestResult
+	synctest.Test(t, func(bt *testing.T) {
+		parentCtx := opts.Behaviors.Context
+		opts.Behaviors.Context = func() context.Context {
+			ctx := context.Background()
+			if parentCtx != nil {
+				ctx = parentCtx()
+			}
+			if hasDeadline {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, time.Now().Add(remaining))
+				bt.Cleanup(cancel)
+			}
+			return ctx
+		}
+
+		tb := newFakeTB(opts)
+		tb.externalCapture = capture != nil
+		tr = runTest(tb, testFn)
+	})
+
+	if capture != nil {
+		stdout, stderr := capture.stop()
+
+		tr.res.mu.Lock()
+		defer tr.res.mu.Unlock()
+
+		tr.res.stdout = stdout
+		tr.res.stderr = stderr
+	}
+	return tr
+}
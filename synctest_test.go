@@ -0,0 +1,140 @@
+//go:build go1.25
+
+package faket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/prashantv/faket/internal/want"
+)
+
+// pollUntil is a helper that polls `done` with a fixed interval.
+func pollUntil(t testing.TB, interval time.Duration, done func() bool) {
+	t.Helper()
+
+	for range 10 {
+		if done() {
+			return
+		}
+		time.Sleep(interval)
+	}
+	t.Errorf("condition not met after 10 attempts")
+}
+
+func TestRunSynctest(t *testing.T) {
+	realStart := time.Now()
+
+	var elapsed, cleanupElapsed time.Duration
+	tr := RunSynctest(t, func(t testing.TB) {
+		start := time.Now()
+		t.Cleanup(func() {
+			time.Sleep(time.Minute)
+			cleanupElapsed = time.Since(start)
+		})
+
+		pollUntil(t, time.Hour, func() bool { return false })
+		elapsed = time.Since(start)
+	})
+
+	want.Equal(t, "Outcome", tr.Outcome(), OutcomeFail)
+	want.DeepEqual(t, "Messages", tr.Logs().Messages(), []string{"condition not met after 10 attempts"})
+	tr.MustReportFromCaller(t)
+
+	want.Equal(t, "fake time elapsed", elapsed, 10*time.Hour)
+	want.Equal(t, "fake time elapsed in cleanup", cleanupElapsed, 10*time.Hour+time.Minute)
+	if realElapsed := time.Since(realStart); realElapsed > time.Minute {
+		t.Errorf("expected fake time to advance instantly, took %v", realElapsed)
+	}
+}
+
+func TestRunSynctestWait(t *testing.T) {
+	RunSynctest(t, func(t testing.TB) {
+		var done bool
+		go func() {
+			time.Sleep(time.Second)
+			done = true
+		}()
+
+		time.Sleep(time.Second)
+		synctest.Wait()
+		if !done {
+			t.Error("goroutine did not complete")
+		}
+	}).MustPass(t)
+}
+
+func TestRunSynctestContext(t *testing.T) {
+	deadline, hasDeadline := t.Deadline()
+
+	RunSynctest(t, func(t testing.TB) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+
+		start := time.Now()
+		<-ctx.Done()
+		want.Equal(t, "timeout elapsed", time.Since(start), time.Second)
+
+		gotDeadline, ok := t.Context().Deadline()
+		want.Equal(t, "has deadline", ok, hasDeadline)
+		if !hasDeadline {
+			return
+		}
+
+		// The deadline is within the real test's remaining time,
+		// and cancels the context when reached on the bubble's clock.
+		if remaining := gotDeadline.Sub(time.Now()); remaining > time.Until(deadline) {
+			t.Errorf("context deadline in %v is after the test deadline", remaining)
+		}
+		<-t.Context().Done()
+		want.Equal(t, "context error", errors.Is(t.Context().Err(), context.DeadlineExceeded), true)
+	}).MustPass(t)
+}
+
+func TestRunSynctestOpts(t *testing.T) {
+	type ctxKey struct{}
+
+	var events []string
+	RunSynctestOpts(t, Opts{
+		Hooks: []Hooks{recordHooks("", &events)},
+		Behaviors: Behaviors{
+			Context: func() context.Context {
+				return context.WithValue(context.Background(), ctxKey{}, "value")
+			},
+		},
+	}, func(t testing.TB) {
+		want.Equal(t, "context value", t.Context().Value(ctxKey{}), any("value"))
+		t.Log("log")
+	}).MustPass(t)
+
+	want.DeepEqual(t, "events", events, []string{"log log: log"})
+}
+
+func TestRunSynctestCaptureOutput(t *testing.T) {
+	stdout := os.Stdout
+
+	result := make(chan TestResult, 1)
+	go func() {
+		result <- RunSynctestOpts(t, Opts{CaptureOutput: true}, func(t testing.TB) {
+			start := time.Now()
+			time.Sleep(time.Hour)
+			fmt.Println("slept", time.Since(start))
+		})
+	}()
+
+	var tr TestResult
+	select {
+	case tr = <-result:
+	case <-time.After(10 * time.Second):
+		t.Fatal("time did not advance in the bubble while capturing output")
+	}
+
+	tr.MustPass(t)
+	want.Equal(t, "Stdout", tr.Stdout(), "slept 1h0m0s\n")
+	want.Equal(t, "restored os.Stdout", os.Stdout, stdout)
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/prashantv/faket/internal/diff"
+	"github.com/prashantv/faket/internal/sliceutil"
 )
 
 // MustPass ensures the test passed.
@@ -12,7 +15,8 @@ func (tr TestResult) MustPass(t testing.TB) {
 	t.Helper()
 
 	if tr.Failed() {
-		t.Fatalf("test failed, logs:\n%v", tr.Logs())
+		logs := tr.Logs()
+		t.Fatalf("test failed, failures:\n%vlogs:\n%v", logs.failures(), logs.Format(FormatOpts{Kind: true}))
 	}
 }
 
@@ -26,8 +30,130 @@ func (tr TestResult) MustFail(t testing.TB, wantLog string) {
 		t.Fatal("test passed, but expected to fail")
 	}
 
-	if !strings.Contains(tr.Logs().String(), wantLog) {
-		t.Fatalf("test expected to fail, missing expected log %q. logs:\n%v", wantLog, tr.Logs())
+	logs := tr.Logs()
+	if !strings.Contains(logs.String(), wantLog) {
+		t.Fatalf("test expected to fail, missing expected log %q%s\nlogs:\n%v",
+			wantLog, closestDiff(wantLog, logs.Messages()), logs)
+	}
+}
+
+// MustFailWith ensures that the test failed, and each matcher matches
+// a separate log, in the same order as the logs.
+// Otherwise, it will report a fatal failure to `t`.
+func (tr TestResult) MustFailWith(t testing.TB, matchers ...LogMatcher) {
+	t.Helper()
+
+	tr.MustFailWithOpts(t, MatchOpts{}, matchers...)
+}
+
+// MustFailWithOpts is the same as [TestResult.MustFailWith],
+// but supports options for customizing how logs are matched.
+func (tr TestResult) MustFailWithOpts(t testing.TB, opts MatchOpts, matchers ...LogMatcher) {
+	t.Helper()
+
+	if !tr.Failed() {
+		t.Fatal("test passed, but expected to fail")
+	}
+
+	logs := tr.Logs()
+	if m := matchLogs(logs, matchers, opts); !m.ok() {
+		t.Fatalf("test expected to fail, logs did not match:\n%vlogs:\n%v", m, logs)
+	}
+}
+
+// MustReportFromCaller ensures that the test failed, and every error and fatal log
+// is attributed to the test function passed to [RunTest], rather than a helper.
+// This verifies that helpers call [testing.TB].Helper.
+// Otherwise, it will report a fatal failure to `t`.
+//
+// Results from [Spy] have no test function, so use [TestResult.MustFailAt] instead.
+func (tr TestResult) MustReportFromCaller(t testing.TB) {
+	t.Helper()
+
+	tr.mustHaveTestFn(t)
+
+	// Method values (e.g., s.testMethod) use a wrapper with a "-fm" suffix,
+	// while logs are attributed to the method.
+	testFn, _, _ := funcInfo(tr.res.testFn)
+	testFn = strings.TrimSuffix(testFn, "-fm")
+	tr.mustReportFrom(t, "test function "+testFn, func(l Log) bool {
+		return l.CallerFunc == testFn
+	})
+}
+
+// MustFailAt ensures that the test failed, and every error and fatal log
+// is attributed to a source line containing `marker`, such as a comment.
+// Otherwise, it will report a fatal failure to `t`.
+func (tr TestResult) MustFailAt(t testing.TB, marker string) {
+	t.Helper()
+
+	tr.mustReportFrom(t, fmt.Sprintf("line containing %q", marker), func(l Log) bool {
+		line, err := sourceLine(l.CallerFile, l.CallerLine)
+		return err == nil && strings.Contains(line, marker)
+	})
+}
+
+// MustNotPrint ensures the test didn't write to os.Stdout, os.Stderr or
+// the standard logger, which verifies that helpers report using `t`.
+// The test must be run with [Opts.CaptureOutput].
+// Otherwise, it will report a fatal failure to `t`.
+func (tr TestResult) MustNotPrint(t testing.TB) {
+	t.Helper()
+
+	if !tr.res.opts.CaptureOutput {
+		t.Fatal("cannot check output, the test was not run with Opts.CaptureOutput")
+	}
+
+	var buf strings.Builder
+	for _, out := range []struct{ name, output string }{
+		{"stdout", tr.Stdout()},
+		{"stderr", tr.Stderr()},
+	} {
+		if out.output != "" {
+			fmt.Fprintf(&buf, "%v:\n%v", out.name, indentLines(strings.TrimSuffix(out.output, "\n")+"\n"))
+		}
+	}
+	if buf.Len() > 0 {
+		t.Fatalf("test wrote output outside of t:\n%v", buf.String())
+	}
+}
+
+// mustHaveTestFn ensures the result has a test function, which results
+// from Spy don't have.
+func (tr TestResult) mustHaveTestFn(t testing.TB) {
+	t.Helper()
+
+	if tr.res.testFn == 0 {
+		t.Fatal("cannot find the test function, results from Spy are not supported")
+	}
+}
+
+func (tr TestResult) mustReportFrom(t testing.TB, want string, match func(Log) bool) {
+	t.Helper()
+
+	if !tr.Failed() {
+		t.Fatal("test passed, but expected to fail")
+	}
+
+	var reported int
+	var mismatched Logs
+	for _, l := range tr.Logs() {
+		if l.Kind != LogKindError && l.Kind != LogKindFatal {
+			continue
+		}
+
+		reported++
+		if !match(l) {
+			mismatched = append(mismatched, l)
+		}
+	}
+
+	if reported == 0 {
+		t.Fatal("test failed, but no errors were logged")
+	}
+	if len(mismatched) > 0 {
+		t.Fatalf("expected failures to be reported from %v (missing t.Helper() call?), got:\n%v",
+			want, mismatched.Format(FormatOpts{CallerFunc: true}))
 	}
 }
 
@@ -41,8 +167,49 @@ func (tr TestResult) MustPanic(t testing.TB, contains string) {
 		t.Fatal("test did not panic, but expected to panic")
 	}
 
-	rec := tr.res.recovered
-	if !strings.Contains(fmt.Sprint(rec), contains) {
-		t.Fatalf("test expected to panic, panic string doesn't contain %q. got:\n%v", contains, rec)
+	rec := fmt.Sprint(tr.res.recovered)
+	if !strings.Contains(rec, contains) {
+		t.Fatalf("test expected to panic, panic string doesn't contain %q. got:\n%v%s",
+			contains, rec, closestDiff(contains, []string{rec}))
+	}
+}
+
+// failures returns logs that caused the test to fail.
+func (ls Logs) failures() Logs {
+	var failures Logs
+	for _, l := range ls {
+		if l.isFailure() {
+			failures = append(failures, l)
+		}
+	}
+	return failures
+}
+
+func (l Log) isFailure() bool {
+	switch l.Kind {
+	case LogKindError, LogKindFatal, LogKindPanic:
+		return true
+	default:
+		return false
+	}
+}
+
+// closestDiff returns a diff of want against the most similar candidate.
+func closestDiff(want string, candidates []string) string {
+	i := diff.Closest(want, candidates)
+	if i < 0 {
+		return ""
 	}
+
+	return "\nclosest log diff (-want +got):\n" + indentLines(diff.Lines(want, candidates[i]))
+}
+
+func indentLines(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	return strings.Join(sliceutil.Map(lines, func(l string) string {
+		if l == "" {
+			return l
+		}
+		return indent + l
+	}), "")
 }
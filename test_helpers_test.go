@@ -1,6 +1,7 @@
 package faket
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/prashantv/faket/internal/want"
@@ -83,3 +84,178 @@ func TestMustHelpers(t *testing.T) {
 		})
 	}
 }
+
+func TestMustHelpersDiff(t *testing.T) {
+	t.Run("MustFail shows closest log", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			t.Log("unrelated log")
+			t.Error("count: expected equal\ngot:  0\nwant: 4")
+			t.Log("another log")
+		})
+
+		tr := RunTest(func(t testing.TB) {
+			fnTR.MustFail(t, "got:  0\nwant: 5")
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Contains(t, "Message", tr.Logs().String(), "closest log diff (-want +got):\n"+
+			"    +count: expected equal\n"+
+			"     got:  0\n"+
+			"    -want: 5\n"+
+			"    +want: 4\n")
+	})
+
+	t.Run("MustFail with no logs", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			t.Fail()
+		})
+
+		tr := RunTest(func(t testing.TB) {
+			fnTR.MustFail(t, "message")
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.NotContains(t, "Message", tr.Logs().String(), "closest log diff")
+	})
+
+	t.Run("MustPanic shows diff", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			panic("panicked with value")
+		})
+
+		tr := RunTest(func(t testing.TB) {
+			fnTR.MustPanic(t, "expected value")
+		})
+		want.Contains(t, "Message", tr.Logs().String(), "    -expected value\n    +panicked with value\n")
+	})
+
+	t.Run("MustPass shows failures", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			t.Log("passing log")
+			t.Error("failing log")
+		})
+
+		tr := RunTest(func(t testing.TB) {
+			fnTR.MustPass(t)
+		})
+		msg := tr.Logs()[0].Message
+		want.Contains(t, "Message", msg, "failures:\ntest_helpers_test.go:")
+		want.Contains(t, "Message", msg, "[error] failing log")
+		want.NotContains(t, "Message", strings.Split(msg, "logs:")[0], "passing log")
+	})
+}
+
+func TestMustReportFromCaller(t *testing.T) {
+	tests := []struct {
+		name         string
+		fn           func(testing.TB)
+		wantFail     bool
+		wantContains string
+	}{
+		{
+			name: "helper calls t.Helper",
+			fn: func(t testing.TB) {
+				t.Log("logs are ignored")
+				errorHelper(t, "failed")
+			},
+		},
+		{
+			name: "helper is missing t.Helper",
+			fn: func(t testing.TB) {
+				errorNotHelper(t, "failed")
+			},
+			wantFail:     true,
+			wantContains: "faket.errorNotHelper: failed",
+		},
+		{
+			name: "method value",
+			fn:   (&reportSuite{}).errorViaHelper,
+		},
+		{
+			name:         "method value with helper missing t.Helper",
+			fn:           (&reportSuite{}).errorViaNotHelper,
+			wantFail:     true,
+			wantContains: "faket.errorNotHelper: failed",
+		},
+		{
+			name:         "passing test",
+			fn:           func(t testing.TB) {},
+			wantFail:     true,
+			wantContains: "test passed, but expected to fail",
+		},
+		{
+			name: "no errors logged",
+			fn: func(t testing.TB) {
+				t.Fail()
+			},
+			wantFail:     true,
+			wantContains: "no errors were logged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fnTR := RunTest(tt.fn)
+			tr := RunTest(func(t testing.TB) {
+				fnTR.MustReportFromCaller(t)
+			})
+			want.Equal(t, "Failed", tr.Failed(), tt.wantFail)
+			if tt.wantContains != "" {
+				want.Contains(t, "Message", tr.Logs().String(), tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestMustReportFromCallerSpy(t *testing.T) {
+	var spyTR TestResult
+	RunTest(func(t testing.TB) {
+		spy, spyResult := Spy(t)
+		errorHelper(spy, "failed")
+		spyTR = spyResult()
+	})
+
+	tr := RunTest(func(t testing.TB) {
+		spyTR.MustReportFromCaller(t)
+	})
+	tr.MustFail(t, "cannot find the test function, results from Spy are not supported")
+}
+
+type reportSuite struct{}
+
+func (s *reportSuite) errorViaHelper(t testing.TB) {
+	errorHelper(t, "failed")
+}
+
+func (s *reportSuite) errorViaNotHelper(t testing.TB) {
+	errorNotHelper(t, "failed")
+}
+
+func TestMustFailAt(t *testing.T) {
+	t.Run("failures at marker", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			errorHelper(t, "failed 1") // fail here
+			t.Log("logs are ignored")
+			errorHelper(t, "failed 2") // fail here
+		})
+		fnTR.MustFailAt(t, "// fail here")
+	})
+
+	t.Run("failure in helper", func(t *testing.T) {
+		fnTR := RunTest(func(t testing.TB) {
+			errorNotHelper(t, "failed") // fail here
+		})
+		tr := RunTest(func(t testing.TB) {
+			fnTR.MustFailAt(t, "// fail here")
+		})
+		want.Equal(t, "Failed", tr.Failed(), true)
+		want.Contains(t, "Message", tr.Logs().String(), `reported from line containing "// fail here"`)
+	})
+}
+
+func errorHelper(t testing.TB, msg string) {
+	t.Helper()
+	t.Error(msg)
+}
+
+func errorNotHelper(t testing.TB, msg string) {
+	t.Error(msg)
+}
@@ -2,9 +2,7 @@ package faket
 
 import (
 	"fmt"
-	"path/filepath"
 	"sort"
-	"strings"
 
 	"github.com/prashantv/faket/internal/sliceutil"
 )
@@ -26,6 +24,71 @@ type Log struct {
 
 	// TBFunc is the testing.TB function that generated this log message.
 	TBFunc string
+
+	// Kind is the kind of log, based on the testing.TB function used.
+	Kind LogKind
+}
+
+// LogKind is the kind of a log entry.
+type LogKind int
+
+// LogKind values.
+const (
+	// LogKindLog is used for Log and Logf.
+	LogKindLog LogKind = iota + 1
+	// LogKindError is used for Error and Errorf.
+	LogKindError
+	// LogKindFatal is used for Fatal and Fatalf.
+	LogKindFatal
+	// LogKindSkip is used for Skip and Skipf.
+	LogKindSkip
+	// LogKindPanic is used for the log added when a test panics.
+	LogKindPanic
+)
+
+func (k LogKind) String() string {
+	switch k {
+	case LogKindLog:
+		return "log"
+	case LogKindError:
+		return "error"
+	case LogKindFatal:
+		return "fatal"
+	case LogKindSkip:
+		return "skip"
+	case LogKindPanic:
+		return "panic"
+	default:
+		return fmt.Sprintf("LogKind(%d)", int(k))
+	}
+}
+
+// Outcome is the final status of a test.
+type Outcome int
+
+// Outcome values.
+const (
+	// OutcomePass is used for tests that passed.
+	OutcomePass Outcome = iota + 1
+	// OutcomeFail is used for tests that failed, including panics
+	// and tests that were skipped after failing.
+	OutcomeFail
+	// OutcomeSkip is used for tests that were skipped.
+	OutcomeSkip
+)
+
+// String returns the outcome as printed by `go test`, e.g., "PASS".
+func (o Outcome) String() string {
+	switch o {
+	case OutcomePass:
+		return "PASS"
+	case OutcomeFail:
+		return "FAIL"
+	case OutcomeSkip:
+		return "SKIP"
+	default:
+		return fmt.Sprintf("Outcome(%d)", int(o))
+	}
 }
 
 // Failed reports if a test failed.
@@ -61,6 +124,18 @@ func (r TestResult) FailedAndSkipped() bool {
 	return r.res.Failed() && r.res.Skipped()
 }
 
+// Outcome returns the final status of the test.
+func (r TestResult) Outcome() Outcome {
+	switch {
+	case r.Failed():
+		return OutcomeFail
+	case r.Skipped():
+		return OutcomeSkip
+	default:
+		return OutcomePass
+	}
+}
+
 // Helpers returns a list of functions that have called [testing.TB].Helper.
 // The returned list is sorted by the full package+function.
 func (r TestResult) Helpers() []string {
@@ -69,9 +144,52 @@ func (r TestResult) Helpers() []string {
 	return funcs
 }
 
+// TempDirs returns the directories created by [testing.TB].TempDir,
+// in the order they were created.
+func (r TestResult) TempDirs() []string {
+	r.res.mu.Lock()
+	defer r.res.mu.Unlock()
+
+	return append([]string(nil), r.res.tempDirs...)
+}
+
+// Exited reports if the test process exited before the test completed.
+// This is only possible for tests run using [RunIsolated].
+func (r TestResult) Exited() bool {
+	return r.res.exited
+}
+
+// ExitCode returns the exit code of the process that ran the test.
+// This is only set for tests run using [RunIsolated].
+func (r TestResult) ExitCode() int {
+	return r.res.exitCode
+}
+
+// Stdout returns the output written to os.Stdout by the test.
+// This is only set for tests run with [Opts.CaptureOutput].
+func (r TestResult) Stdout() string {
+	r.res.mu.Lock()
+	defer r.res.mu.Unlock()
+
+	return string(r.res.stdout)
+}
+
+// Stderr returns the output written to os.Stderr or the standard logger
+// by the test, for tests run with [Opts.CaptureOutput].
+//
+// For tests run using [RunIsolated], it's the output written to stderr by
+// the process that ran the test, such as the output of log.Fatal
+// or a fatal runtime error.
+func (r TestResult) Stderr() string {
+	r.res.mu.Lock()
+	defer r.res.mu.Unlock()
+
+	return string(r.res.stderr)
+}
+
 // Logs returns a list of log entries logged by the test.
 func (r TestResult) Logs() Logs {
-	return sliceutil.Map(r.res.logs, r.res.toLog)
+	return r.res.resolvedLogs()
 }
 
 // Messages returns a list of individual logs.
@@ -83,10 +201,7 @@ func (ls Logs) Messages() []string {
 
 // String returns the log output, as it would be printed by `go test`
 // with caller information.
+// See [Logs.Format] for more control over the output.
 func (ls Logs) String() string {
-	var buf strings.Builder
-	for _, l := range ls {
-		fmt.Fprintf(&buf, "%s:%d: %v\n", filepath.Base(l.CallerFile), l.CallerLine, l.Message)
-	}
-	return buf.String()
+	return ls.Format(FormatOpts{})
 }